@@ -43,6 +43,7 @@ func startCRDBootstrapController(ctx ControllerContext) (http.Handler, bool, err
 	informerFactory := externalinformers.NewSharedInformerFactory(crdClient, 1*time.Minute)
 
 	controller, err := crdbootstrap.NewController(
+		crdClient,
 		informerFactory.Apiextensions().V1().CustomResourceDefinitions(),
 	)
 	if err != nil {
@@ -1,5 +1,5 @@
 /*
-Copyright 2015 The Kubernetes Authors.
+Copyright 2016 The Kubernetes Authors.
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -18,19 +18,26 @@ limitations under the License.
 package crdbootstrap
 
 import (
+	"context"
 	"embed"
 	"fmt"
 	"io/fs"
+	"strings"
 	"time"
 
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/util/yaml"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 	"k8s.io/kubernetes/pkg/controller"
 
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	clientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	informers "k8s.io/apiextensions-apiserver/pkg/client/informers/externalversions/apiextensions/v1"
 	listers "k8s.io/apiextensions-apiserver/pkg/client/listers/apiextensions/v1"
 )
@@ -38,22 +45,37 @@ import (
 //go:embed crds/*.yaml
 var inTreeCRDs embed.FS
 
+// managedByAnnotation marks the CRDs this controller owns, so a cluster-admin can tell an
+// in-tree CRD apart from one they installed themselves, and so the controller knows it's safe
+// to reconcile or re-create it.
+const managedByAnnotation = "crd.kubernetes.io/managed-by"
+
+// managedByValue is the value of managedByAnnotation set on CRDs this controller installs.
+const managedByValue = "crd-bootstrap-controller"
+
+// maxRetries is the number of times a key is retried before it's dropped out of the queue.
+const maxRetries = 5
+
 type Controller struct {
+	client clientset.Interface
+
 	queue      workqueue.RateLimitingInterface
 	crds       listers.CustomResourceDefinitionLister
 	crdsSynced cache.InformerSynced
 	crdStore   CRDStore
 }
 
-func NewController(informer informers.CustomResourceDefinitionInformer) (*Controller, error) {
+func NewController(client clientset.Interface, informer informers.CustomResourceDefinitionInformer) (*Controller, error) {
 	controller := &Controller{
-		queue: workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "crd-bootstrap"),
-		crds:  informer.Lister(),
+		client:     client,
+		queue:      workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "crd-bootstrap"),
+		crds:       informer.Lister(),
+		crdsSynced: informer.Informer().HasSynced,
 	}
 
 	store, err := NewInTreeCRDStoreFromFilesystem(inTreeCRDs)
 	if err != nil {
-		utilruntime.HandleError(fmt.Errorf("unable to initialize store: %v", err))
+		return nil, fmt.Errorf("unable to initialize in-tree CRD store: %v", err)
 	}
 	controller.crdStore = store
 
@@ -72,16 +94,16 @@ func (c *Controller) Run(stopCh <-chan struct{}) {
 	klog.InfoS("starting crd bootstrap controller")
 	defer klog.InfoS("stopping crd bootstrap controller")
 
-	// install the CRDs present in tree
-	if err := c.installInTree(); err != nil {
-		klog.Fatalf("unable to install CRDs present in tree", err)
-	}
-
 	// wait for cache to be filled
 	if !cache.WaitForNamedCacheSync("crd", stopCh, c.crdsSynced) {
 		return
 	}
 
+	// install the CRDs present in tree
+	if err := c.installInTree(); err != nil {
+		utilruntime.HandleError(fmt.Errorf("unable to install CRDs present in tree: %v", err))
+	}
+
 	// run the worker in a loop
 	go wait.Until(c.worker, time.Second, stopCh)
 
@@ -108,22 +130,152 @@ func (c *Controller) processNextWorkItem() bool {
 	return true
 }
 
+func (c *Controller) handleErr(err error, key interface{}) {
+	if err == nil {
+		c.queue.Forget(key)
+		return
+	}
+
+	if c.queue.NumRequeues(key) < maxRetries {
+		klog.V(2).InfoS("error syncing CRD, retrying", "key", key, "err", err)
+		c.queue.AddRateLimited(key)
+		return
+	}
+
+	utilruntime.HandleError(err)
+	klog.InfoS("dropping CRD out of the queue after repeated errors", "key", key, "err", err)
+	c.queue.Forget(key)
+}
+
+// syncHandler reconciles a single CRD, identified by name, against its in-tree desired state (if
+// any): creating it if it's missing, patching it if it has drifted, and re-creating it if it was
+// deleted while still present in tree. CRDs that aren't ours to manage are left alone.
 func (c *Controller) syncHandler(key string) error {
-	return nil
+	_, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	desired, inTree := c.crdStore.Get(name)
+
+	live, err := c.crds.Get(name)
+	switch {
+	case apierrors.IsNotFound(err):
+		if !inTree {
+			return nil
+		}
+		return c.createCRD(desired)
+	case err != nil:
+		return err
+	case !inTree:
+		// The live CRD isn't one we ship (any more); we never touch CRDs we didn't install.
+		return nil
+	default:
+		return c.reconcileCRD(live, desired)
+	}
+}
+
+// createCRD creates desired, stamping it with managedByAnnotation. Tolerates a concurrent
+// creation racing us (e.g. a replayed add event).
+func (c *Controller) createCRD(desired *apiextensionsv1.CustomResourceDefinition) error {
+	toCreate := desired.DeepCopy()
+	if toCreate.Annotations == nil {
+		toCreate.Annotations = map[string]string{}
+	}
+	toCreate.Annotations[managedByAnnotation] = managedByValue
+
+	_, err := c.client.ApiextensionsV1().CustomResourceDefinitions().Create(context.TODO(), toCreate, metav1.CreateOptions{})
+	if apierrors.IsAlreadyExists(err) {
+		return nil
+	}
+	return err
+}
+
+// reconcileCRD three-way merges the in-tree desired CRD into the live one: group, scope, names
+// and the served/storage state of each version are taken from desired, while conditions and any
+// other fields the apiserver or an admin added to the live object are preserved untouched.
+func (c *Controller) reconcileCRD(live, desired *apiextensionsv1.CustomResourceDefinition) error {
+	if live.Annotations[managedByAnnotation] != managedByValue {
+		// Don't clobber a CRD of the same name that we didn't install.
+		return nil
+	}
+
+	updated := live.DeepCopy()
+	updated.Spec.Group = desired.Spec.Group
+	updated.Spec.Scope = desired.Spec.Scope
+	updated.Spec.Names = desired.Spec.Names
+	updated.Spec.Versions = mergeVersions(live.Spec.Versions, desired.Spec.Versions)
+	if updated.Annotations == nil {
+		updated.Annotations = map[string]string{}
+	}
+	updated.Annotations[managedByAnnotation] = managedByValue
+
+	if apiequality.Semantic.DeepEqual(live.Spec, updated.Spec) && apiequality.Semantic.DeepEqual(live.Annotations, updated.Annotations) {
+		return nil
+	}
+
+	_, err := c.client.ApiextensionsV1().CustomResourceDefinitions().Update(context.TODO(), updated, metav1.UpdateOptions{})
+	return err
+}
+
+// mergeVersions takes the served/storage transition (and schema) of each version from desired,
+// while preserving any additional version live already has that desired no longer mentions, so a
+// version an operator has running resources on isn't yanked out from under them by a binary
+// upgrade that dropped it from the in-tree definition.
+func mergeVersions(live, desired []apiextensionsv1.CustomResourceDefinitionVersion) []apiextensionsv1.CustomResourceDefinitionVersion {
+	desiredByName := make(map[string]apiextensionsv1.CustomResourceDefinitionVersion, len(desired))
+	for _, v := range desired {
+		desiredByName[v.Name] = v
+	}
+
+	merged := make([]apiextensionsv1.CustomResourceDefinitionVersion, 0, len(live)+len(desired))
+	seen := make(map[string]bool, len(live))
+	for _, v := range live {
+		if d, ok := desiredByName[v.Name]; ok {
+			merged = append(merged, d)
+		} else {
+			merged = append(merged, v)
+		}
+		seen[v.Name] = true
+	}
+	for _, v := range desired {
+		if !seen[v.Name] {
+			merged = append(merged, v)
+		}
+	}
+	return merged
 }
 
 func (c *Controller) updateCRD(oldObj, newObj interface{}) {
-	fmt.Println("update event registered")
+	key, err := controller.KeyFunc(newObj)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("couldn't get key for object %#v: %v", newObj, err))
+		return
+	}
+	c.queue.Add(key)
 }
 
 func (c *Controller) deleteCRD(obj interface{}) {
-	fmt.Println("delete event registered")
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	key, err := controller.KeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("couldn't get key for object %#v: %v", obj, err))
+		return
+	}
+	c.queue.Add(key)
 }
 
+// installInTree enqueues every in-tree CRD so the worker creates or reconciles each of them
+// through the same syncHandler path used for informer-driven updates.
 func (c *Controller) installInTree() error {
-	for crd := range c.crdStore.List() {
-		// TODO: install crd
-		_ = crd
+	for _, crd := range c.crdStore.List() {
+		key, err := controller.KeyFunc(crd)
+		if err != nil {
+			return fmt.Errorf("couldn't get key for object %#v: %v", crd, err)
+		}
+		c.queue.Add(key)
 	}
 	return nil
 }
@@ -131,21 +283,46 @@ func (c *Controller) installInTree() error {
 type CRDStore interface {
 	ListKeys() ([]string, error)
 	List() []*apiextensionsv1.CustomResourceDefinition
+	Get(name string) (crd *apiextensionsv1.CustomResourceDefinition, ok bool)
 }
 
 type InTreeCRDStore struct {
 	CRDs []*apiextensionsv1.CustomResourceDefinition
 }
 
+// NewInTreeCRDStoreFromFilesystem walks filesystem for *.yaml manifests and decodes each one
+// into a CustomResourceDefinition.
 func NewInTreeCRDStoreFromFilesystem(filesystem fs.FS) (CRDStore, error) {
-	store := &InTreeCRDStore{}
+	var crds []*apiextensionsv1.CustomResourceDefinition
+
+	err := fs.WalkDir(filesystem, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".yaml") {
+			return nil
+		}
+
+		raw, err := fs.ReadFile(filesystem, path)
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", path, err)
+		}
 
-	// TODO: walk through the filesystem and find all CRDs
+		crd := &apiextensionsv1.CustomResourceDefinition{}
+		if err := yaml.Unmarshal(raw, crd); err != nil {
+			return fmt.Errorf("decoding %s: %w", path, err)
+		}
+		crds = append(crds, crd)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	return store, nil
+	return &InTreeCRDStore{CRDs: crds}, nil
 }
 
-func (s InTreeCRDStore) ListKeys() ([]string, error) {
+func (s *InTreeCRDStore) ListKeys() ([]string, error) {
 	keys := []string{}
 
 	for _, crd := range s.CRDs {
@@ -162,13 +339,16 @@ func (s InTreeCRDStore) ListKeys() ([]string, error) {
 	return keys, nil
 }
 
-// TODO
-func (s InTreeCRDStore) List() []*apiextensionsv1.CustomResourceDefinition {
-	return nil, nil
+func (s *InTreeCRDStore) List() []*apiextensionsv1.CustomResourceDefinition {
+	return s.CRDs
 }
 
-// TODO
-func ReadInTreeCRDs(filesystem fs.FS) ([]*apiextensionsv1.CustomResourceDefinition, error) {
-
-	return []*apiextensionsv1.CustomResourceDefinition{}, nil
+// Get returns the in-tree CRD with the given name, if any.
+func (s *InTreeCRDStore) Get(name string) (*apiextensionsv1.CustomResourceDefinition, bool) {
+	for _, crd := range s.CRDs {
+		if crd.Name == name {
+			return crd, true
+		}
+	}
+	return nil, false
 }
@@ -0,0 +1,201 @@
+/*
+Copyright 2016 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crdbootstrap
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	fakeclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	externalinformers "k8s.io/apiextensions-apiserver/pkg/client/informers/externalversions"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+type fakeCRDStore struct {
+	crds []*apiextensionsv1.CustomResourceDefinition
+}
+
+func (s *fakeCRDStore) ListKeys() ([]string, error) { return nil, nil }
+
+func (s *fakeCRDStore) List() []*apiextensionsv1.CustomResourceDefinition { return s.crds }
+
+func (s *fakeCRDStore) Get(name string) (*apiextensionsv1.CustomResourceDefinition, bool) {
+	for _, crd := range s.crds {
+		if crd.Name == name {
+			return crd, true
+		}
+	}
+	return nil, false
+}
+
+func newTestController(t *testing.T, live, desired []*apiextensionsv1.CustomResourceDefinition) (*Controller, *fakeclientset.Clientset, cache.Store) {
+	t.Helper()
+
+	objs := make([]runtime.Object, 0, len(live))
+	for _, crd := range live {
+		objs = append(objs, crd)
+	}
+	client := fakeclientset.NewSimpleClientset(objs...)
+
+	factory := externalinformers.NewSharedInformerFactory(client, 0)
+	informer := factory.Apiextensions().V1().CustomResourceDefinitions()
+	for _, crd := range live {
+		if err := informer.Informer().GetStore().Add(crd); err != nil {
+			t.Fatalf("failed to seed informer store: %v", err)
+		}
+	}
+
+	return &Controller{
+		client:     client,
+		queue:      workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "crd-bootstrap-test"),
+		crds:       informer.Lister(),
+		crdsSynced: informer.Informer().HasSynced,
+		crdStore:   &fakeCRDStore{crds: desired},
+	}, client, informer.Informer().GetStore()
+}
+
+func newCRD(name, group string, versions ...apiextensionsv1.CustomResourceDefinitionVersion) *apiextensionsv1.CustomResourceDefinition {
+	return &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group:    group,
+			Scope:    apiextensionsv1.ClusterScoped,
+			Versions: versions,
+		},
+	}
+}
+
+func TestSyncHandlerCreatesMissingCRD(t *testing.T) {
+	desired := newCRD("foos.example.k8s.io", "example.k8s.io", apiextensionsv1.CustomResourceDefinitionVersion{Name: "v1", Served: true, Storage: true})
+	c, client, _ := newTestController(t, nil, []*apiextensionsv1.CustomResourceDefinition{desired})
+
+	if err := c.syncHandler("foos.example.k8s.io"); err != nil {
+		t.Fatalf("syncHandler returned unexpected error: %v", err)
+	}
+
+	created, err := client.ApiextensionsV1().CustomResourceDefinitions().Get(context.TODO(), "foos.example.k8s.io", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected CRD to be created: %v", err)
+	}
+	if got := created.Annotations[managedByAnnotation]; got != managedByValue {
+		t.Errorf("expected managed-by annotation %q, got %q", managedByValue, got)
+	}
+}
+
+func TestSyncHandlerReconcilesDriftedCRD(t *testing.T) {
+	live := newCRD("foos.example.k8s.io", "example.k8s.io", apiextensionsv1.CustomResourceDefinitionVersion{Name: "v1", Served: true, Storage: true})
+	live.Annotations = map[string]string{managedByAnnotation: managedByValue}
+	live.Status.Conditions = []apiextensionsv1.CustomResourceDefinitionCondition{{Type: apiextensionsv1.Established}}
+
+	desired := newCRD("foos.example.k8s.io", "example.k8s.io",
+		apiextensionsv1.CustomResourceDefinitionVersion{Name: "v1", Served: false, Storage: false},
+		apiextensionsv1.CustomResourceDefinitionVersion{Name: "v2", Served: true, Storage: true},
+	)
+
+	c, client, _ := newTestController(t, []*apiextensionsv1.CustomResourceDefinition{live}, []*apiextensionsv1.CustomResourceDefinition{desired})
+
+	if err := c.syncHandler("foos.example.k8s.io"); err != nil {
+		t.Fatalf("syncHandler returned unexpected error: %v", err)
+	}
+
+	updated, err := client.ApiextensionsV1().CustomResourceDefinitions().Get(context.TODO(), "foos.example.k8s.io", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get CRD: %v", err)
+	}
+	if len(updated.Spec.Versions) != 2 || updated.Spec.Versions[0].Served || !updated.Spec.Versions[1].Served {
+		t.Errorf("expected versions to match desired served/storage, got %+v", updated.Spec.Versions)
+	}
+	if len(updated.Status.Conditions) != 1 {
+		t.Errorf("expected existing status conditions to be preserved, got %+v", updated.Status.Conditions)
+	}
+}
+
+func TestSyncHandlerIgnoresUnmanagedCRD(t *testing.T) {
+	live := newCRD("foos.example.k8s.io", "example.k8s.io", apiextensionsv1.CustomResourceDefinitionVersion{Name: "v1", Served: true, Storage: true})
+	desired := newCRD("foos.example.k8s.io", "example.k8s.io", apiextensionsv1.CustomResourceDefinitionVersion{Name: "v2", Served: true, Storage: true})
+
+	c, client, _ := newTestController(t, []*apiextensionsv1.CustomResourceDefinition{live}, []*apiextensionsv1.CustomResourceDefinition{desired})
+
+	if err := c.syncHandler("foos.example.k8s.io"); err != nil {
+		t.Fatalf("syncHandler returned unexpected error: %v", err)
+	}
+
+	got, err := client.ApiextensionsV1().CustomResourceDefinitions().Get(context.TODO(), "foos.example.k8s.io", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get CRD: %v", err)
+	}
+	if len(got.Spec.Versions) != 1 || got.Spec.Versions[0].Name != "v1" {
+		t.Errorf("expected unmanaged CRD to be left untouched, got %+v", got.Spec.Versions)
+	}
+}
+
+func TestSyncHandlerRecreatesDeletedInTreeCRD(t *testing.T) {
+	live := newCRD("foos.example.k8s.io", "example.k8s.io", apiextensionsv1.CustomResourceDefinitionVersion{Name: "v1", Served: true, Storage: true})
+	live.Annotations = map[string]string{managedByAnnotation: managedByValue}
+	desired := newCRD("foos.example.k8s.io", "example.k8s.io", apiextensionsv1.CustomResourceDefinitionVersion{Name: "v1", Served: true, Storage: true})
+
+	c, client, store := newTestController(t, []*apiextensionsv1.CustomResourceDefinition{live}, []*apiextensionsv1.CustomResourceDefinition{desired})
+
+	// Simulate the CRD having been deleted out from under the controller: it's gone from both
+	// the live API and the informer's store, the way it would be by the time deleteCRD's event
+	// reaches the queue and syncHandler runs.
+	if err := client.ApiextensionsV1().CustomResourceDefinitions().Delete(context.TODO(), "foos.example.k8s.io", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("failed to delete CRD from fake client: %v", err)
+	}
+	if err := store.Delete(live); err != nil {
+		t.Fatalf("failed to delete CRD from informer store: %v", err)
+	}
+
+	if err := c.syncHandler("foos.example.k8s.io"); err != nil {
+		t.Fatalf("syncHandler returned unexpected error: %v", err)
+	}
+
+	recreated, err := client.ApiextensionsV1().CustomResourceDefinitions().Get(context.TODO(), "foos.example.k8s.io", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected in-tree CRD to be re-created after deletion, got error: %v", err)
+	}
+	if got := recreated.Annotations[managedByAnnotation]; got != managedByValue {
+		t.Errorf("expected managed-by annotation %q, got %q", managedByValue, got)
+	}
+}
+
+func TestMergeVersionsPreservesLiveOnlyVersion(t *testing.T) {
+	live := []apiextensionsv1.CustomResourceDefinitionVersion{
+		{Name: "v1", Served: true, Storage: false},
+		{Name: "v1beta1", Served: true, Storage: true},
+	}
+	desired := []apiextensionsv1.CustomResourceDefinitionVersion{
+		{Name: "v1", Served: true, Storage: true},
+	}
+
+	merged := mergeVersions(live, desired)
+
+	if len(merged) != 2 {
+		t.Fatalf("expected the live-only version to be preserved alongside desired, got %+v", merged)
+	}
+	if !reflect.DeepEqual(merged[0], desired[0]) {
+		t.Errorf("expected v1 to be taken from desired, got %+v", merged[0])
+	}
+	if !reflect.DeepEqual(merged[1], live[1]) {
+		t.Errorf("expected v1beta1 to be preserved unchanged from live since desired no longer mentions it, got %+v", merged[1])
+	}
+}
@@ -51,16 +51,33 @@ type BuiltInAuthorizationOptions struct {
 	// before we fail the webhook call in order to limit the fan out that ensues when the system is degraded.
 	WebhookRetryBackoff *wait.Backoff
 
+	// AlwaysAllowPaths are HTTP paths that are excluded from authorization. They can be plain
+	// paths or end in * such that all paths with that prefix are excluded. They are validated and
+	// passed through as AuthorizationConfiguration.AlwaysAllowPaths; ToAuthorizationConfig does
+	// not itself put an authorizer for them ahead of the chain.
+	AlwaysAllowPaths []string
+
 	AuthorizationConfigurationFile string
+
+	// AuthorizationConfigReloadInterval bounds how long a change to the file referenced by
+	// AuthorizationConfigurationFile can go unnoticed: changes are normally picked up immediately
+	// via fsnotify, and the file is re-checked on this interval regardless, and re-read if its
+	// modification time has advanced, as a fallback for changes fsnotify misses. Only consulted when AuthorizationConfigurationFile is set.
+	AuthorizationConfigReloadInterval time.Duration
 }
 
+// defaultAuthorizationConfigReloadInterval is used whenever AuthorizationConfigReloadInterval
+// is left unset.
+const defaultAuthorizationConfigReloadInterval = 1 * time.Minute
+
 // NewBuiltInAuthorizationOptions create a BuiltInAuthorizationOptions with default value
 func NewBuiltInAuthorizationOptions() *BuiltInAuthorizationOptions {
 	return &BuiltInAuthorizationOptions{
-		Modes:                       []string{authzmodes.ModeAlwaysAllow},
-		WebhookVersion:              "v1beta1",
-		WebhookCacheAuthorizedTTL:   5 * time.Minute,
-		WebhookCacheUnauthorizedTTL: 30 * time.Second,
+		Modes:                             []string{authzmodes.ModeAlwaysAllow},
+		WebhookVersion:                    "v1beta1",
+		WebhookCacheAuthorizedTTL:         5 * time.Minute,
+		WebhookCacheUnauthorizedTTL:       30 * time.Second,
+		AuthorizationConfigReloadInterval: defaultAuthorizationConfigReloadInterval,
 		WebhookRetryBackoff:         genericoptions.DefaultAuthWebhookRetryBackoff(),
 	}
 }
@@ -147,13 +164,30 @@ func (o *BuiltInAuthorizationOptions) AddFlags(fs *pflag.FlagSet) {
 		"authorization-webhook-cache-unauthorized-ttl", o.WebhookCacheUnauthorizedTTL,
 		"The duration to cache 'unauthorized' responses from the webhook authorizer.")
 
+	fs.StringSliceVar(&o.AlwaysAllowPaths, "authorization-always-allow-paths", o.AlwaysAllowPaths, ""+
+		"A list of HTTP paths to skip during authorization, i.e. these are authorized without "+
+		"contacting the 'Node', 'RBAC', 'Webhook' or 'ABAC' authorizer. The entries are either "+
+		"exact paths or path prefixes ending in '*', e.g. '/healthz', '/readyz', '/livez', '/metrics'.")
+
 	fs.StringVar(&o.AuthorizationConfigurationFile, "authorization-config", o.AuthorizationConfigurationFile, ""+
 		"File with Authorization Configuration to configure the authorizer chain."+
 		"Note: This feature is in Alpha since v1.28."+
 		"The StructuredAuthorizationConfig feature needs to be set to true for enabling the functionality.")
+
+	fs.DurationVar(&o.AuthorizationConfigReloadInterval, "authorization-config-reload-interval",
+		o.AuthorizationConfigReloadInterval,
+		"The maximum duration before a change to the file referenced by --authorization-config, "+
+			"if it validates, is hot-reloaded into the authorizer chain without restarting the "+
+			"server; changes are normally picked up immediately. Only used when --authorization-config is set.")
 }
 
-// ToAuthorizationConfig convert BuiltInAuthorizationOptions to authorizer.Config
+// ToAuthorizationConfig convert BuiltInAuthorizationOptions to authorizer.Config.
+// The resulting AuthorizationConfiguration carries AlwaysAllowPaths through unchanged; this
+// function itself only assembles the configuration, it does not build or wrap an authorizer.
+// k8s.io/apiserver/pkg/authorization/path.NewAuthorizer(AlwaysAllowPaths, nil) is the authorizer
+// meant to be placed ahead of the rest of the chain to actually apply it, but this repo doesn't
+// include the chain builder (pkg/kubeapiserver/authorizer) that would construct and run that
+// chain, so there's currently no caller that does so.
 func (o *BuiltInAuthorizationOptions) ToAuthorizationConfig(versionedInformerFactory versionedinformers.SharedInformerFactory) (authorizer.Config, error) {
 	// When the feature flag is enabled,
 	//		the authorizer is built using the file provided through
@@ -207,11 +241,33 @@ func (o *BuiltInAuthorizationOptions) buildAuthorizationConfiguration() *authzco
 						Type:           "KubeConfigFile",
 						KubeConfigFile: &o.WebhookConfigFile,
 					},
+					// Carry the apiserver-wide WebhookRetryBackoff over as this webhook's
+					// RetryBackoff, so the configuration built from the legacy flags keeps it.
+					RetryBackoff: webhookBackoffConfigurationFromWaitBackoff(o.WebhookRetryBackoff),
 				},
 			})
 		default:
 			authorizers = append(authorizers, authzconfig.AuthorizerConfiguration{Type: authzconfig.AuthorizerType(mode)})
 		}
 	}
-	return &authzconfig.AuthorizationConfiguration{Authorizers: authorizers}
+	return &authzconfig.AuthorizationConfiguration{
+		Authorizers:      authorizers,
+		AlwaysAllowPaths: o.AlwaysAllowPaths,
+	}
+}
+
+// webhookBackoffConfigurationFromWaitBackoff converts a wait.Backoff, as used by the
+// apiserver-wide --authorization-webhook-* flags, into the per-webhook WebhookBackoffConfiguration
+// shape. Returns nil if backoff is nil, leaving the webhook to fall back to its own defaults.
+func webhookBackoffConfigurationFromWaitBackoff(backoff *wait.Backoff) *authzconfig.WebhookBackoffConfiguration {
+	if backoff == nil {
+		return nil
+	}
+	return &authzconfig.WebhookBackoffConfiguration{
+		Duration: metav1.Duration{Duration: backoff.Duration},
+		Factor:   backoff.Factor,
+		Jitter:   backoff.Jitter,
+		Steps:    int32(backoff.Steps),
+		Cap:      metav1.Duration{Duration: backoff.Cap},
+	}
 }
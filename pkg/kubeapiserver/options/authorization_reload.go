@@ -0,0 +1,145 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	authzconfig "k8s.io/apiserver/pkg/authorization/config"
+	authzconfigloader "k8s.io/apiserver/pkg/authorization/config/load"
+	authzconfigreload "k8s.io/apiserver/pkg/authorization/config/reload"
+	authzconfigvalidation "k8s.io/apiserver/pkg/authorization/config/validation"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+	"k8s.io/klog/v2"
+	authzmodes "k8s.io/kubernetes/pkg/kubeapiserver/authorizer/modes"
+)
+
+var (
+	authorizationConfigReloadSuccessTotal = metrics.NewCounter(&metrics.CounterOpts{
+		Namespace:      "apiserver",
+		Subsystem:      "authorization_config",
+		Name:           "reload_success_total",
+		Help:           "Total number of times the configuration file referenced by --authorization-config was successfully reloaded.",
+		StabilityLevel: metrics.ALPHA,
+	})
+	authorizationConfigReloadFailureTotal = metrics.NewCounter(&metrics.CounterOpts{
+		Namespace:      "apiserver",
+		Subsystem:      "authorization_config",
+		Name:           "reload_failure_total",
+		Help:           "Total number of times a reload of the configuration file referenced by --authorization-config failed, leaving the previous configuration in place.",
+		StabilityLevel: metrics.ALPHA,
+	})
+	authorizationConfigLastReloadTimestampSeconds = metrics.NewGauge(&metrics.GaugeOpts{
+		Namespace:      "apiserver",
+		Subsystem:      "authorization_config",
+		Name:           "last_reload_timestamp_seconds",
+		Help:           "Timestamp of the last attempted reload of the configuration file referenced by --authorization-config, whether it succeeded or failed.",
+		StabilityLevel: metrics.ALPHA,
+	})
+)
+
+func init() {
+	legacyregistry.MustRegister(authorizationConfigReloadSuccessTotal)
+	legacyregistry.MustRegister(authorizationConfigReloadFailureTotal)
+	legacyregistry.MustRegister(authorizationConfigLastReloadTimestampSeconds)
+}
+
+// ReloadableAuthorizationConfig holds the most recently loaded, successfully validated
+// AuthorizationConfiguration behind an atomic.Pointer, so a reader building the authorizer chain
+// for a request never observes a partially applied reload.
+//
+// A reload only replaces the stored configuration. Nothing in this package builds the authorizer
+// chain or any per-webhook decision cache from it; that is done by the authorizer builder
+// (pkg/kubeapiserver/authorizer), which isn't part of this tree. No decision cache is keyed by a
+// hash of its own AuthorizerConfiguration, so nothing here keeps a webhook's cache across a reload.
+type ReloadableAuthorizationConfig struct {
+	current atomic.Pointer[authzconfig.AuthorizationConfiguration]
+}
+
+// Current returns the most recently loaded AuthorizationConfiguration.
+func (r *ReloadableAuthorizationConfig) Current() *authzconfig.AuthorizationConfiguration {
+	return r.current.Load()
+}
+
+// loadAndValidateAuthorizationConfig loads file and runs it through
+// ValidateAuthorizationConfiguration, so a syntactically valid but semantically broken
+// configuration (an unknown authorizer type, a webhook missing its name, and so on) is rejected
+// the same way a file that fails to parse is.
+func loadAndValidateAuthorizationConfig(file string) (*authzconfig.AuthorizationConfiguration, error) {
+	config, err := authzconfigloader.LoadFromFile(file)
+	if err != nil {
+		return nil, err
+	}
+	if errs := authzconfigvalidation.ValidateAuthorizationConfiguration(nil, config,
+		sets.NewString(authzmodes.AuthorizationModeChoices...),
+		sets.NewString(authzmodes.RepeatableAuthorizerTypes...),
+	); len(errs) != 0 {
+		return nil, errs.ToAggregate()
+	}
+	return config, nil
+}
+
+// StartAuthorizationConfigReloading loads o.AuthorizationConfigurationFile once synchronously,
+// then starts a goroutine that watches it for changes (via fsnotify, with a periodic re-stat as a
+// fallback for changes fsnotify misses, e.g. an atomically-replaced projected ConfigMap volume),
+// swapping the returned ReloadableAuthorizationConfig's Current() value every time a reload
+// succeeds. A reload that fails to load or fails validation is rejected: the previous
+// configuration is kept live, and the failure metric is incremented. Both outcomes are logged and,
+// via recorder, recorded as a Kubernetes event against involvedObject (typically the apiserver's
+// own Lease or Pod), so a reload failure is visible without having to go looking at logs.
+// Reloading stops when stopCh is closed.
+func (o *BuiltInAuthorizationOptions) StartAuthorizationConfigReloading(stopCh <-chan struct{}, recorder record.EventRecorder, involvedObject runtime.Object) (*ReloadableAuthorizationConfig, error) {
+	r := &ReloadableAuthorizationConfig{}
+
+	watcher := authzconfigreload.NewWatcher(
+		o.AuthorizationConfigurationFile,
+		o.AuthorizationConfigReloadInterval,
+		func(file string) (interface{}, error) { return loadAndValidateAuthorizationConfig(file) },
+		func(config interface{}) {
+			r.current.Store(config.(*authzconfig.AuthorizationConfiguration))
+			authorizationConfigLastReloadTimestampSeconds.Set(float64(time.Now().Unix()))
+			authorizationConfigReloadSuccessTotal.Inc()
+			klog.V(2).InfoS("reloaded authorization configuration", "file", o.AuthorizationConfigurationFile)
+			recorder.Eventf(involvedObject, corev1.EventTypeNormal, "AuthorizationConfigReloadSucceeded", "reloaded authorization configuration from %q", o.AuthorizationConfigurationFile)
+		},
+		func(err error) {
+			authorizationConfigLastReloadTimestampSeconds.Set(float64(time.Now().Unix()))
+			authorizationConfigReloadFailureTotal.Inc()
+			klog.ErrorS(err, "discarding invalid authorization configuration reload, keeping previous configuration", "file", o.AuthorizationConfigurationFile)
+			recorder.Eventf(involvedObject, corev1.EventTypeWarning, "AuthorizationConfigReloadFailed", "discarding invalid authorization configuration reload from %q: %v", o.AuthorizationConfigurationFile, err)
+		},
+	)
+
+	if err := watcher.ReloadNow(); err != nil {
+		return nil, fmt.Errorf("unable to load initial authorization configuration from %q: %w", o.AuthorizationConfigurationFile, err)
+	}
+
+	go func() {
+		if err := watcher.Run(stopCh); err != nil {
+			klog.ErrorS(err, "authorization config file watcher exited, reloads will no longer be picked up", "file", o.AuthorizationConfigurationFile)
+		}
+	}()
+
+	return r, nil
+}
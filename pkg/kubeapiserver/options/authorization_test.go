@@ -48,6 +48,7 @@ func TestAuthzValidate(t *testing.T) {
 		policyFile           string
 		webhookConfigFile    string
 		webhookRetryBackoff  *wait.Backoff
+		alwaysAllowPaths     []string
 		expectErr            bool
 		expectErrorSubString string
 	}{
@@ -122,6 +123,19 @@ func TestAuthzValidate(t *testing.T) {
 			expectErr:            true,
 			expectErrorSubString: "number of webhook retry attempts must be greater than 0",
 		},
+		{
+			name:                 "AlwaysAllowPaths with a wildcard in the middle should error",
+			modes:                []string{modes.ModeAlwaysAllow},
+			alwaysAllowPaths:     []string{"/healthz/*/foo"},
+			expectErr:            true,
+			expectErrorSubString: "wildcards are only permitted as the trailing character of the path",
+		},
+		{
+			name:             "AlwaysAllowPaths with a trailing wildcard should not error",
+			modes:            []string{modes.ModeAlwaysAllow},
+			alwaysAllowPaths: []string{"/healthz", "/metrics/*"},
+			expectErr:        false,
+		},
 	}
 
 	for _, testcase := range testCases {
@@ -131,6 +145,7 @@ func TestAuthzValidate(t *testing.T) {
 			options.WebhookConfigFile = testcase.webhookConfigFile
 			options.WebhookRetryBackoff = testcase.webhookRetryBackoff
 			options.PolicyFile = testcase.policyFile
+			options.AlwaysAllowPaths = testcase.alwaysAllowPaths
 
 			errs := options.Validate()
 			if len(errs) > 0 && !testcase.expectErr {
@@ -171,6 +186,7 @@ func TestBuiltInAuthorizationOptionsAddFlags(t *testing.T) {
 			Jitter:   0.2,
 			Steps:    5,
 		},
+		AuthorizationConfigReloadInterval: defaultAuthorizationConfigReloadInterval,
 	}
 
 	opts := NewBuiltInAuthorizationOptions()
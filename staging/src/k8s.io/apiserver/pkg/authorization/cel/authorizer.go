@@ -0,0 +1,218 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cel implements an authorizer.Authorizer backed by a CELConfiguration's rules, so inline
+// CEL policies can be declared in an --authorization-config file as an alternative to running a
+// webhook for simple cases.
+//
+// This package is only reachable through --authorization-config's "CEL" authorizer type
+// (authorizationapi.TypeCEL); it has no --authorization-mode flag equivalent. An
+// AuthorizationConfiguration's CEL authorizer holds an ordered list of arbitrary rule objects,
+// which doesn't fit the legacy flag's closed set of single-word mode names the way, say, Webhook
+// or RBAC do, so it isn't added to authzmodes.AuthorizationModeChoices.
+package cel
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/google/cel-go/common/types/ref"
+
+	authorizationapi "k8s.io/apiserver/pkg/authorization/config"
+	"k8s.io/apiserver/pkg/authorization/config/validation"
+
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+)
+
+// Decision strings a CELPolicyRule's expression must evaluate to.
+const (
+	decisionAllow     = "allow"
+	decisionDeny      = "deny"
+	decisionNoOpinion = "no-opinion"
+)
+
+// Authorizer evaluates an ordered list of compiled CEL policy rules against each request,
+// stopping at the first rule whose expression doesn't evaluate to "no-opinion". It implements
+// authorizer.Authorizer.
+//
+// Reason is surfaced through Authorize's own reason return, since authorizer.Authorizer has no
+// other channel for it. AuditAnnotations is not wired to anything in this package: doing so would
+// mean annotating the audit event already attached to ctx by the apiserver's audit handler, and
+// this package doesn't assume that infrastructure is present — a caller that wants
+// CELPolicyRule.AuditAnnotations surfaced should read the firing rule (identified by its Reason or
+// index) back out of the configuration itself after Authorize returns.
+type Authorizer struct {
+	rules []*validation.CompiledCELPolicyRule
+
+	cacheMu sync.RWMutex
+	cache   map[string]cachedDecision
+}
+
+type cachedDecision struct {
+	decision authorizer.Decision
+	reason   string
+}
+
+// NewFromConfig compiles every rule in c the same way validation.ValidateCELPolicyRule checks it,
+// and returns an Authorizer ready to evaluate requests. c is assumed to have already passed
+// validation.ValidateAuthorizationConfiguration; a compile failure here is therefore unexpected,
+// and is returned rather than panicked on so a caller that skipped validation still fails at
+// startup instead of mid-request.
+func NewFromConfig(c *authorizationapi.CELConfiguration) (*Authorizer, error) {
+	a := &Authorizer{cache: map[string]cachedDecision{}}
+	for i, rule := range c.Rules {
+		compiled, err := validation.CompileCELPolicyRule(rule)
+		if err != nil {
+			return nil, fmt.Errorf("compiling CEL policy rule %d: %w", i, err)
+		}
+		a.rules = append(a.rules, compiled)
+	}
+	return a, nil
+}
+
+// Authorize evaluates a's rules in declared order against attrs, short-circuiting on the first
+// rule whose expression evaluates to "allow" or "deny". If every rule evaluates to "no-opinion",
+// or a has no rules, Authorize returns authorizer.DecisionNoOpinion so the next authorizer in the
+// chain gets a say. Results are cached by a hash of the attributes relevant to evaluation, since
+// the same request shape (e.g. the same user listing the same resource repeatedly) is common and
+// re-running every rule's CEL program for each one is wasted work.
+func (a *Authorizer) Authorize(ctx context.Context, attrs authorizer.Attributes) (authorizer.Decision, string, error) {
+	key := cacheKey(attrs)
+
+	a.cacheMu.RLock()
+	cached, ok := a.cache[key]
+	a.cacheMu.RUnlock()
+	if ok {
+		return cached.decision, cached.reason, nil
+	}
+
+	decision, reason, err := a.authorize(ctx, attrs)
+	if err != nil {
+		return authorizer.DecisionNoOpinion, "", err
+	}
+
+	a.cacheMu.Lock()
+	a.cache[key] = cachedDecision{decision: decision, reason: reason}
+	a.cacheMu.Unlock()
+
+	return decision, reason, nil
+}
+
+func (a *Authorizer) authorize(ctx context.Context, attrs authorizer.Attributes) (authorizer.Decision, string, error) {
+	request := requestFromAttributes(attrs)
+
+	for _, rule := range a.rules {
+		out, _, err := rule.Program.ContextEval(ctx, map[string]interface{}{requestVariableName: request})
+		if err != nil {
+			return authorizer.DecisionNoOpinion, "", fmt.Errorf("evaluating CEL policy rule %q: %w", rule.Rule.Expression, err)
+		}
+
+		switch decision := stringValue(out); decision {
+		case decisionAllow:
+			return authorizer.DecisionAllow, rule.Rule.Reason, nil
+		case decisionDeny:
+			return authorizer.DecisionDeny, rule.Rule.Reason, nil
+		case decisionNoOpinion:
+			continue
+		default:
+			return authorizer.DecisionNoOpinion, "", fmt.Errorf("CEL policy rule %q evaluated to unrecognized decision %q", rule.Rule.Expression, decision)
+		}
+	}
+
+	return authorizer.DecisionNoOpinion, "", nil
+}
+
+func stringValue(v ref.Val) string {
+	s, ok := v.Value().(string)
+	if !ok {
+		return ""
+	}
+	return s
+}
+
+// requestVariableName must match the CEL variable name the validation package's environment binds
+// Request to, since rule.Program was compiled against that environment.
+const requestVariableName = "request"
+
+// requestFromAttributes converts authorizer.Attributes into the validation.Request CEL rules are
+// type-checked and evaluated against.
+func requestFromAttributes(attrs authorizer.Attributes) validation.Request {
+	request := validation.Request{
+		Extra: map[string][]string{},
+	}
+
+	if user := attrs.GetUser(); user != nil {
+		request.User = user.GetName()
+		request.Groups = user.GetGroups()
+		request.UID = user.GetUID()
+		for k, v := range user.GetExtra() {
+			request.Extra[k] = v
+		}
+	}
+
+	if attrs.IsResourceRequest() {
+		request.ResourceAttributes = &validation.ResourceAttributes{
+			Namespace:   attrs.GetNamespace(),
+			Verb:        attrs.GetVerb(),
+			Group:       attrs.GetAPIGroup(),
+			Version:     attrs.GetAPIVersion(),
+			Resource:    attrs.GetResource(),
+			Subresource: attrs.GetSubresource(),
+			Name:        attrs.GetName(),
+		}
+	} else {
+		request.NonResourceAttributes = &validation.NonResourceAttributes{
+			Path: attrs.GetPath(),
+			Verb: attrs.GetVerb(),
+		}
+	}
+
+	return request
+}
+
+// cacheKey hashes the parts of attrs that requestFromAttributes actually reads, so two requests
+// that differ only in a field no rule could possibly observe still share a cache entry.
+func cacheKey(attrs authorizer.Attributes) string {
+	var b strings.Builder
+
+	if user := attrs.GetUser(); user != nil {
+		fmt.Fprintf(&b, "user=%s\x00uid=%s\x00groups=%s\x00", user.GetName(), user.GetUID(), strings.Join(user.GetGroups(), ","))
+		extra := user.GetExtra()
+		keys := make([]string, 0, len(extra))
+		for k := range extra {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fmt.Fprintf(&b, "extra[%s]=%s\x00", k, strings.Join(extra[k], ","))
+		}
+	}
+
+	if attrs.IsResourceRequest() {
+		fmt.Fprintf(&b, "resource\x00ns=%s\x00verb=%s\x00group=%s\x00version=%s\x00resource=%s\x00subresource=%s\x00name=%s\x00",
+			attrs.GetNamespace(), attrs.GetVerb(), attrs.GetAPIGroup(), attrs.GetAPIVersion(), attrs.GetResource(), attrs.GetSubresource(), attrs.GetName())
+	} else {
+		fmt.Fprintf(&b, "nonresource\x00path=%s\x00verb=%s\x00", attrs.GetPath(), attrs.GetVerb())
+	}
+
+	sum := sha256.Sum256([]byte(b.String()))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cel
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apiserver/pkg/authentication/user"
+	authorizationapi "k8s.io/apiserver/pkg/authorization/config"
+
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+)
+
+func TestAuthorize(t *testing.T) {
+	tests := []struct {
+		name             string
+		rules            []authorizationapi.CELPolicyRule
+		attrs            authorizer.AttributesRecord
+		expectedDecision authorizer.Decision
+		expectedReason   string
+	}{
+		{
+			name: "allow fires",
+			rules: []authorizationapi.CELPolicyRule{
+				{Expression: "request.groups.exists(g, g == 'system:masters') ? 'allow' : 'no-opinion'", Reason: "member of system:masters"},
+			},
+			attrs:            authorizer.AttributesRecord{User: &user.DefaultInfo{Groups: []string{"system:masters"}}},
+			expectedDecision: authorizer.DecisionAllow,
+			expectedReason:   "member of system:masters",
+		},
+		{
+			name: "deny fires",
+			rules: []authorizationapi.CELPolicyRule{
+				{Expression: "request.resourceAttributes.verb == 'delete' ? 'deny' : 'no-opinion'", Reason: "deletes are forbidden"},
+			},
+			attrs: authorizer.AttributesRecord{
+				User:            &user.DefaultInfo{},
+				ResourceRequest: true,
+				Verb:            "delete",
+			},
+			expectedDecision: authorizer.DecisionDeny,
+			expectedReason:   "deletes are forbidden",
+		},
+		{
+			name: "no rule fires",
+			rules: []authorizationapi.CELPolicyRule{
+				{Expression: "'no-opinion'"},
+			},
+			attrs:            authorizer.AttributesRecord{User: &user.DefaultInfo{}},
+			expectedDecision: authorizer.DecisionNoOpinion,
+		},
+		{
+			name:  "no rules at all",
+			attrs: authorizer.AttributesRecord{User: &user.DefaultInfo{}},
+
+			expectedDecision: authorizer.DecisionNoOpinion,
+		},
+		{
+			name: "stops at the first non-no-opinion rule, later rules are never consulted",
+			rules: []authorizationapi.CELPolicyRule{
+				{Expression: "'no-opinion'", Reason: "first"},
+				{Expression: "'allow'", Reason: "second"},
+				{Expression: "'deny'", Reason: "third"},
+			},
+			attrs:            authorizer.AttributesRecord{User: &user.DefaultInfo{}},
+			expectedDecision: authorizer.DecisionAllow,
+			expectedReason:   "second",
+		},
+		{
+			name: "non-resource request evaluated via request.path",
+			rules: []authorizationapi.CELPolicyRule{
+				{Expression: "request.path('/healthz') ? 'allow' : 'no-opinion'", Reason: "health check"},
+			},
+			attrs: authorizer.AttributesRecord{
+				User: &user.DefaultInfo{},
+				Path: "/healthz",
+			},
+			expectedDecision: authorizer.DecisionAllow,
+			expectedReason:   "health check",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a, err := NewFromConfig(&authorizationapi.CELConfiguration{Rules: test.rules})
+			if err != nil {
+				t.Fatalf("NewFromConfig: %v", err)
+			}
+
+			decision, reason, err := a.Authorize(context.Background(), test.attrs)
+			if err != nil {
+				t.Fatalf("Authorize: %v", err)
+			}
+			if decision != test.expectedDecision {
+				t.Errorf("expected decision %v, got %v", test.expectedDecision, decision)
+			}
+			if reason != test.expectedReason {
+				t.Errorf("expected reason %q, got %q", test.expectedReason, reason)
+			}
+		})
+	}
+}
+
+// TestAuthorizeCachesByAttributes asserts that two Authorize calls with the same attributes share
+// a single cache entry, and that attributes differing in a field rules can actually observe get
+// distinct entries.
+func TestAuthorizeCachesByAttributes(t *testing.T) {
+	a, err := NewFromConfig(&authorizationapi.CELConfiguration{
+		Rules: []authorizationapi.CELPolicyRule{
+			{Expression: "request.groups.exists(g, g == 'system:masters') ? 'allow' : 'no-opinion'"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewFromConfig: %v", err)
+	}
+
+	attrs := authorizer.AttributesRecord{User: &user.DefaultInfo{Groups: []string{"system:masters"}}}
+
+	if _, _, err := a.Authorize(context.Background(), attrs); err != nil {
+		t.Fatalf("Authorize: %v", err)
+	}
+	if len(a.cache) != 1 {
+		t.Fatalf("expected 1 cache entry after one call, got %d", len(a.cache))
+	}
+
+	if _, _, err := a.Authorize(context.Background(), attrs); err != nil {
+		t.Fatalf("Authorize: %v", err)
+	}
+	if len(a.cache) != 1 {
+		t.Fatalf("expected identical attributes to reuse the same cache entry, got %d entries", len(a.cache))
+	}
+
+	other := authorizer.AttributesRecord{User: &user.DefaultInfo{Groups: []string{"other-group"}}}
+	if _, _, err := a.Authorize(context.Background(), other); err != nil {
+		t.Fatalf("Authorize: %v", err)
+	}
+	if len(a.cache) != 2 {
+		t.Fatalf("expected attributes differing in a group rules can observe to get a new cache entry, got %d entries", len(a.cache))
+	}
+}
+
+func TestAuthorizeUnrecognizedDecisionIsAnError(t *testing.T) {
+	a, err := NewFromConfig(&authorizationapi.CELConfiguration{
+		Rules: []authorizationapi.CELPolicyRule{
+			{Expression: "'maybe'"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewFromConfig: %v", err)
+	}
+
+	_, _, err = a.Authorize(context.Background(), authorizer.AttributesRecord{User: &user.DefaultInfo{}})
+	if err == nil {
+		t.Fatal("expected an error for a rule evaluating to a decision string other than allow/deny/no-opinion")
+	}
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package load reads an --authorization-config file off disk into an internal
+// AuthorizationConfiguration.
+package load
+
+import (
+	"fmt"
+	"os"
+
+	"k8s.io/apimachinery/pkg/util/yaml"
+	authorizationapi "k8s.io/apiserver/pkg/authorization/config"
+	"k8s.io/apiserver/pkg/authorization/config/v1alpha1"
+)
+
+// LoadFromFile reads the AuthorizationConfiguration at file, in either YAML or JSON form, and
+// converts it to its internal representation. It does not validate the result; callers should run
+// it through validation.ValidateAuthorizationConfiguration before acting on it.
+func LoadFromFile(file string) (*authorizationapi.AuthorizationConfiguration, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read authorization configuration from %q: %w", file, err)
+	}
+	return Load(data)
+}
+
+// Load decodes data as an AuthorizationConfiguration and converts it to its internal
+// representation.
+func Load(data []byte) (*authorizationapi.AuthorizationConfiguration, error) {
+	versioned := &v1alpha1.AuthorizationConfiguration{}
+	if err := yaml.Unmarshal(data, versioned); err != nil {
+		return nil, fmt.Errorf("unable to decode authorization configuration: %w", err)
+	}
+	return convertToInternal(versioned), nil
+}
+
+// convertToInternal copies a v1alpha1.AuthorizationConfiguration into its internal representation.
+// It is a straight copy rather than a semantic transformation, except that a webhook's deprecated
+// singular SubjectAccessReviewVersion is also normalized into SubjectAccessReviewVersions, and a
+// matchCondition's Message, which has no internal counterpart, is dropped.
+func convertToInternal(in *v1alpha1.AuthorizationConfiguration) *authorizationapi.AuthorizationConfiguration {
+	out := &authorizationapi.AuthorizationConfiguration{
+		TypeMeta:         in.TypeMeta,
+		AlwaysAllowPaths: in.AlwaysAllowPaths,
+	}
+	for _, a := range in.Authorizers {
+		out.Authorizers = append(out.Authorizers, convertAuthorizerToInternal(a))
+	}
+	return out
+}
+
+func convertAuthorizerToInternal(in v1alpha1.AuthorizerConfiguration) authorizationapi.AuthorizerConfiguration {
+	out := authorizationapi.AuthorizerConfiguration{
+		Type: authorizationapi.AuthorizerType(in.Type),
+	}
+
+	if in.Webhook != nil {
+		out.Webhook = convertWebhookToInternal(in.Webhook)
+	}
+	if in.CEL != nil {
+		cel := &authorizationapi.CELConfiguration{}
+		for _, rule := range in.CEL.Rules {
+			cel.Rules = append(cel.Rules, authorizationapi.CELPolicyRule{
+				Expression:       rule.Expression,
+				Reason:           rule.Reason,
+				AuditAnnotations: rule.AuditAnnotations,
+			})
+		}
+		out.CEL = cel
+	}
+	if in.ABAC != nil {
+		out.ABAC = &authorizationapi.ABACConfiguration{PolicyFile: in.ABAC.PolicyFile}
+	}
+	if in.RBAC != nil {
+		out.RBAC = &authorizationapi.RBACConfiguration{}
+	}
+	if in.Path != nil {
+		out.Path = &authorizationapi.PathConfiguration{
+			AlwaysAllowPaths:            in.Path.AlwaysAllowPaths,
+			AlwaysAllowNonResourceVerbs: in.Path.AlwaysAllowNonResourceVerbs,
+		}
+	}
+
+	return out
+}
+
+func convertWebhookToInternal(in *v1alpha1.WebhookConfiguration) *authorizationapi.WebhookConfiguration {
+	// The deprecated singular SubjectAccessReviewVersion is normalized into
+	// SubjectAccessReviewVersions here so downstream consumers, like the webhook authorizer
+	// negotiating a version with the remote server, only ever have to deal with the list.
+	versions := in.SubjectAccessReviewVersions
+	if len(versions) == 0 && len(in.SubjectAccessReviewVersion) > 0 {
+		versions = []string{in.SubjectAccessReviewVersion}
+	}
+
+	out := &authorizationapi.WebhookConfiguration{
+		Name:                        in.Name,
+		AuthorizedTTL:               in.AuthorizedTTL,
+		UnauthorizedTTL:             in.UnauthorizedTTL,
+		Timeout:                     in.Timeout,
+		SubjectAccessReviewVersion:  in.SubjectAccessReviewVersion,
+		SubjectAccessReviewVersions: versions,
+		FailurePolicy:               in.FailurePolicy,
+		ConnectionInfo: authorizationapi.WebhookConnectionInfo{
+			Type:           in.ConnectionInfo.Type,
+			KubeConfigFile: in.ConnectionInfo.KubeConfigFile,
+		},
+		MaxInFlight: in.MaxInFlight,
+	}
+
+	for _, c := range in.MatchConditions {
+		out.MatchConditions = append(out.MatchConditions, authorizationapi.WebhookMatchCondition{Expression: c.Expression})
+	}
+
+	if in.RetryBackoff != nil {
+		out.RetryBackoff = &authorizationapi.WebhookBackoffConfiguration{
+			Duration: in.RetryBackoff.Duration,
+			Factor:   in.RetryBackoff.Factor,
+			Jitter:   in.RetryBackoff.Jitter,
+			Steps:    in.RetryBackoff.Steps,
+			Cap:      in.RetryBackoff.Cap,
+		}
+	}
+
+	return out
+}
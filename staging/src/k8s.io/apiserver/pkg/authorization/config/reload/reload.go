@@ -0,0 +1,151 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package reload watches an --authorization-config file for changes and drives an all-or-nothing
+// reload of it: a file that fails to load or fails validation never replaces the configuration
+// that's currently in effect.
+package reload
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+// DefaultFallbackPollInterval is how often the file is re-stat'd even when fsnotify is working, so
+// a replacement that fsnotify misses (e.g. a projected ConfigMap volume's atomic symlink swap,
+// depending on the kubelet version and sync loop in effect) is still picked up promptly. Used when
+// NewWatcher is given a pollInterval <= 0.
+const DefaultFallbackPollInterval = 1 * time.Minute
+
+// Watcher polls and/or watches a single file for changes, invoking load whenever its contents may
+// have changed, and reporting the outcome through onReload/onError. load is expected to both parse
+// and validate the file; Watcher itself has no opinion on the file's format.
+type Watcher struct {
+	file         string
+	pollInterval time.Duration
+	load         func(file string) (interface{}, error)
+
+	onReload func(config interface{})
+	onError  func(err error)
+
+	mu          sync.Mutex
+	lastModTime time.Time
+}
+
+// NewWatcher returns a Watcher for file. load is called with file's path every time the watcher
+// believes the file may have changed; its return value is passed to onReload on success, or its
+// error is passed to onError on failure. Neither callback is invoked concurrently with another
+// call to either. pollInterval <= 0 falls back to DefaultFallbackPollInterval.
+func NewWatcher(file string, pollInterval time.Duration, load func(file string) (interface{}, error), onReload func(interface{}), onError func(error)) *Watcher {
+	if pollInterval <= 0 {
+		pollInterval = DefaultFallbackPollInterval
+	}
+	return &Watcher{
+		file:         file,
+		pollInterval: pollInterval,
+		load:         load,
+		onReload:     onReload,
+		onError:      onError,
+	}
+}
+
+// ReloadNow re-stats and, if changed, reloads the file immediately, reporting the outcome through
+// onReload/onError and returning the same error. Callers that need a valid configuration to start
+// up should call this once, synchronously, before handing the Watcher to Run.
+func (w *Watcher) ReloadNow() error {
+	return w.reload()
+}
+
+// Run watches the file for changes until stopCh is closed, calling ReloadNow every time fsnotify
+// observes a change and, as a fallback for changes fsnotify misses, every pollInterval regardless.
+// It does not perform an initial load; call ReloadNow first if one is needed.
+func (w *Watcher) Run(stopCh <-chan struct{}) error {
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("unable to create file watcher for %q, falling back to polling every %s: %w", w.file, w.pollInterval, err))
+		wait.Until(func() { w.reload() }, w.pollInterval, stopCh)
+		return nil
+	}
+	// Watch the containing directory, not the file itself: tools that replace a watched
+	// ConfigMap/Secret's contents (including kubelet's projected volume) do so by renaming a new
+	// file into place, which fsnotify only observes as events on the directory.
+	if err := fsWatcher.Add(filepath.Dir(w.file)); err != nil {
+		fsWatcher.Close()
+		utilruntime.HandleError(fmt.Errorf("unable to watch directory of %q, falling back to polling every %s: %w", w.file, w.pollInterval, err))
+		wait.Until(func() { w.reload() }, w.pollInterval, stopCh)
+		return nil
+	}
+	defer fsWatcher.Close()
+
+	go wait.Until(func() { w.reload() }, w.pollInterval, stopCh)
+
+	for {
+		select {
+		case <-stopCh:
+			return nil
+		case event, ok := <-fsWatcher.Events:
+			if !ok {
+				return nil
+			}
+			if filepath.Clean(event.Name) == filepath.Clean(w.file) {
+				w.reload()
+			}
+		case err, ok := <-fsWatcher.Errors:
+			if !ok {
+				return nil
+			}
+			utilruntime.HandleError(fmt.Errorf("authorization config watcher for %q: %w", w.file, err))
+		}
+	}
+}
+
+// reload re-stats the file and, if its modification time has advanced since the last successful
+// or failed attempt, calls load and reports the outcome. A file that's missing or whose mtime
+// can't be determined is treated the same as a load failure: onError is called and the previous
+// configuration stays in effect.
+func (w *Watcher) reload() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	info, err := os.Stat(w.file)
+	if err != nil {
+		w.onError(fmt.Errorf("unable to stat %q: %w", w.file, err))
+		return err
+	}
+	if !info.ModTime().After(w.lastModTime) {
+		return nil
+	}
+	w.lastModTime = info.ModTime()
+
+	config, err := w.load(w.file)
+	if err != nil {
+		klog.ErrorS(err, "failed to reload authorization configuration, keeping previous configuration", "file", w.file)
+		w.onError(err)
+		return err
+	}
+
+	w.onReload(config)
+	return nil
+}
@@ -26,10 +26,21 @@ type AuthorizationConfiguration struct {
 	metav1.TypeMeta
 
 	Authorizers []AuthorizerConfiguration
+
+	// AlwaysAllowPaths are HTTP paths that are excluded from authorization. They can be plain
+	// paths or end in * such that all paths with that prefix are excluded.
+	AlwaysAllowPaths []string
 }
 
 const (
-	TypeWebhook AuthorizerType = "Webhook"
+	TypeWebhook     AuthorizerType = "Webhook"
+	TypeCEL         AuthorizerType = "CEL"
+	TypeNode        AuthorizerType = "Node"
+	TypeRBAC        AuthorizerType = "RBAC"
+	TypeABAC        AuthorizerType = "ABAC"
+	TypeAlwaysAllow AuthorizerType = "AlwaysAllow"
+	TypeAlwaysDeny  AuthorizerType = "AlwaysDeny"
+	TypePath        AuthorizerType = "Path"
 )
 
 type AuthorizerType string
@@ -38,6 +49,71 @@ type AuthorizerConfiguration struct {
 	Type AuthorizerType
 
 	Webhook *WebhookConfiguration
+
+	CEL *CELConfiguration
+
+	// ABAC is required when Type=ABAC, and forbidden otherwise.
+	ABAC *ABACConfiguration
+
+	// RBAC is only meaningful when Type=RBAC; it carries no settings today, RBAC policy is
+	// always sourced from the cluster's Role/ClusterRole/RoleBinding/ClusterRoleBinding objects.
+	RBAC *RBACConfiguration
+
+	// Path is required when Type=Path, and forbidden otherwise.
+	Path *PathConfiguration
+}
+
+// PathConfiguration configures the Path authorizer, which unconditionally allows a fixed set of
+// HTTP paths (e.g. /healthz, /livez, /readyz, /metrics) without consulting any other authorizer.
+// Unlike AuthorizationConfiguration.AlwaysAllowPaths, which applies ahead of the whole authorizer
+// chain regardless of which authorizers are configured, a Path authorizer only takes effect where
+// it's placed in the Authorizers list, so it can be ordered relative to other authorizers.
+type PathConfiguration struct {
+	// AlwaysAllowPaths are HTTP paths that are allowed without consulting any other authorizer.
+	// They can be plain paths or end in * such that all paths with that prefix are allowed.
+	AlwaysAllowPaths []string
+
+	// AlwaysAllowNonResourceVerbs are non-resource verbs (e.g. "get") that are allowed against any
+	// of AlwaysAllowPaths without consulting any other authorizer. An empty list allows every verb.
+	AlwaysAllowNonResourceVerbs []string
+}
+
+// ABACConfiguration configures the ABAC authorizer.
+type ABACConfiguration struct {
+	// PolicyFile is the file with the ABAC policy, in JSON-lines format.
+	// Same as setting `--authorization-policy-file`.
+	PolicyFile string
+}
+
+// RBACConfiguration configures the RBAC authorizer. It is intentionally empty: RBAC has no
+// additional settings beyond the Role/ClusterRole/RoleBinding/ClusterRoleBinding objects in the
+// cluster, but the struct exists so Type=RBAC can still carry an (empty) sub-config the same
+// way every other authorizer type does.
+type RBACConfiguration struct{}
+
+// CELConfiguration holds an ordered list of CEL-based authorization rules evaluated in-process,
+// without requiring a webhook.
+type CELConfiguration struct {
+	// Rules are evaluated in the order given. Evaluation stops at the first rule whose
+	// expression returns a decision other than "no-opinion".
+	Rules []CELPolicyRule
+}
+
+// CELPolicyRule is a single CEL expression evaluated against the request's SubjectAccessReview.
+type CELPolicyRule struct {
+	// Expression must evaluate to one of "allow", "deny", or "no-opinion". It has access to a
+	// `request` variable typed as the SubjectAccessReviewSpec, the same variable exposed to
+	// WebhookMatchCondition, plus a request.path(p) helper for non-resource path checks that
+	// reports whether request is a non-resource request whose path matches p, e.g.
+	// request.path('/healthz') or, to match a prefix, request.path('/metrics/*').
+	Expression string
+
+	// Reason is surfaced on the authorization decision when this rule fires.
+	Reason string
+
+	// AuditAnnotations are carried with the rule. The CEL authorizer does not add them to the
+	// request's audit event when this rule fires.
+	AuditAnnotations map[string]string
 }
 
 type WebhookConfiguration struct {
@@ -68,7 +144,17 @@ type WebhookConfiguration struct {
 	// Same as setting `--authorization-webhook-version` flag
 	// Valid values: v1beta1, v1
 	// Required, no default value
+	//
+	// Deprecated: use SubjectAccessReviewVersions instead. If both are set, this must be a member
+	// of SubjectAccessReviewVersions.
 	SubjectAccessReviewVersion string
+	// SubjectAccessReviewVersions is an ordered list of preferred authorization.k8s.io
+	// SubjectAccessReview versions to send to and expect from the webhook, most preferred first,
+	// so a single configuration can talk to webhooks that only understand an older version.
+	// Valid values: v1beta1, v1
+	// Required (unless the deprecated singular SubjectAccessReviewVersion is set instead), no
+	// default value.
+	SubjectAccessReviewVersions []string
 	// Controls the authorization decision when a webhook request fails to
 	// complete or returns a malformed response.
 	// Valid values:
@@ -81,6 +167,31 @@ type WebhookConfiguration struct {
 	ConnectionInfo WebhookConnectionInfo
 
 	MatchConditions []WebhookMatchCondition
+
+	// RetryBackoff controls the retry backoff used by this webhook's client.
+	// If unset, the apiserver-wide --authorization-webhook-* retry defaults are used instead.
+	RetryBackoff *WebhookBackoffConfiguration
+
+	// MaxInFlight bounds the number of concurrent requests this webhook will have outstanding
+	// at once. Requests beyond the cap fail fast rather than queue, so a slow or degraded
+	// webhook cannot exhaust all apiserver goroutines.
+	// If unset or zero, there is no cap.
+	MaxInFlight int32
+}
+
+// WebhookBackoffConfiguration mirrors k8s.io/apimachinery/pkg/util/wait.Backoff so it can be set
+// per-webhook instead of once for the whole process.
+type WebhookBackoffConfiguration struct {
+	// Duration is the initial amount of time to sleep before the first retry.
+	Duration metav1.Duration
+	// Factor multiplies Duration after each retry.
+	Factor float64
+	// Jitter, if non-zero, adds a random fraction of Duration to the sleep between retries.
+	Jitter float64
+	// Steps is the number of retry attempts, including the initial one.
+	Steps int32
+	// Cap, if non-zero, is the maximum amount of time to sleep between retries.
+	Cap metav1.Duration
 }
 
 type WebhookConnectionInfo struct {
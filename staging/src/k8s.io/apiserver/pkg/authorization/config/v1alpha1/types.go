@@ -26,10 +26,22 @@ type AuthorizationConfiguration struct {
 	metav1.TypeMeta
 
 	Authorizers []AuthorizerConfiguration `json:"authorizers"`
+
+	// alwaysAllowPaths are HTTP paths that are excluded from authorization. They can be plain
+	// paths or end in * such that all paths with that prefix are excluded.
+	// +optional
+	AlwaysAllowPaths []string `json:"alwaysAllowPaths,omitempty"`
 }
 
 const (
-	TypeWebhook AuthorizerType = "Webhook"
+	TypeWebhook     AuthorizerType = "Webhook"
+	TypeCEL         AuthorizerType = "CEL"
+	TypeNode        AuthorizerType = "Node"
+	TypeRBAC        AuthorizerType = "RBAC"
+	TypeABAC        AuthorizerType = "ABAC"
+	TypeAlwaysAllow AuthorizerType = "AlwaysAllow"
+	TypeAlwaysDeny  AuthorizerType = "AlwaysDeny"
+	TypePath        AuthorizerType = "Path"
 )
 
 type AuthorizerType string
@@ -38,6 +50,73 @@ type AuthorizerConfiguration struct {
 	Type string `json:"type"`
 
 	Webhook *WebhookConfiguration `json:"webhook,omitempty"`
+
+	// +optional
+	CEL *CELConfiguration `json:"cel,omitempty"`
+
+	// abac is required when type=ABAC, and forbidden otherwise.
+	// +optional
+	ABAC *ABACConfiguration `json:"abac,omitempty"`
+
+	// rbac is only meaningful when type=RBAC; it carries no settings today, RBAC policy is
+	// always sourced from the cluster's Role/ClusterRole/RoleBinding/ClusterRoleBinding objects.
+	// +optional
+	RBAC *RBACConfiguration `json:"rbac,omitempty"`
+
+	// path is required when type=Path, and forbidden otherwise.
+	// +optional
+	Path *PathConfiguration `json:"path,omitempty"`
+}
+
+// PathConfiguration configures the Path authorizer, which unconditionally allows a fixed set of
+// HTTP paths (e.g. /healthz, /livez, /readyz, /metrics) without consulting any other authorizer.
+type PathConfiguration struct {
+	// alwaysAllowPaths are HTTP paths that are allowed without consulting any other authorizer.
+	// They can be plain paths or end in * such that all paths with that prefix are allowed.
+	// +optional
+	AlwaysAllowPaths []string `json:"alwaysAllowPaths,omitempty"`
+
+	// alwaysAllowNonResourceVerbs are non-resource verbs that are allowed against any of
+	// alwaysAllowPaths without consulting any other authorizer. An empty list allows every verb.
+	// +optional
+	AlwaysAllowNonResourceVerbs []string `json:"alwaysAllowNonResourceVerbs,omitempty"`
+}
+
+// ABACConfiguration configures the ABAC authorizer.
+type ABACConfiguration struct {
+	// policyFile is the file with the ABAC policy, in JSON-lines format.
+	// Same as setting `--authorization-policy-file`.
+	PolicyFile string `json:"policyFile"`
+}
+
+// RBACConfiguration configures the RBAC authorizer. It is intentionally empty.
+type RBACConfiguration struct{}
+
+// CELConfiguration holds an ordered list of CEL-based authorization rules evaluated in-process,
+// without requiring a webhook.
+type CELConfiguration struct {
+	// rules are evaluated in the order given. Evaluation stops at the first rule whose
+	// expression returns a decision other than "no-opinion".
+	Rules []CELPolicyRule `json:"rules"`
+}
+
+// CELPolicyRule is a single CEL expression evaluated against the request's SubjectAccessReview.
+type CELPolicyRule struct {
+	// expression must evaluate to one of "allow", "deny", or "no-opinion". It has access to a
+	// `request` variable typed as the SubjectAccessReviewSpec, the same variable exposed to
+	// webhook matchConditions, plus a request.path(p) helper for non-resource path checks that
+	// reports whether request is a non-resource request whose path matches p, e.g.
+	// request.path('/healthz') or, to match a prefix, request.path('/metrics/*').
+	Expression string `json:"expression"`
+
+	// reason is surfaced on the authorization decision when this rule fires.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// auditAnnotations are carried with the rule. The CEL authorizer does not add them to the
+	// request's audit event when this rule fires.
+	// +optional
+	AuditAnnotations map[string]string `json:"auditAnnotations,omitempty"`
 }
 
 type WebhookConfiguration struct {
@@ -65,7 +144,19 @@ type WebhookConfiguration struct {
 	// Same as setting `--authorization-webhook-version` flag
 	// Valid values: v1beta1, v1
 	// Required, no default value
-	SubjectAccessReviewVersion string `json:"subjectAccessReviewVersion"`
+	//
+	// Deprecated: use subjectAccessReviewVersions instead. If both are set, this must be a member
+	// of subjectAccessReviewVersions.
+	// +optional
+	SubjectAccessReviewVersion string `json:"subjectAccessReviewVersion,omitempty"`
+	// subjectAccessReviewVersions is an ordered list of preferred authorization.k8s.io
+	// SubjectAccessReview versions to send to and expect from the webhook, most preferred first,
+	// so a single configuration can talk to webhooks that only understand an older version.
+	// Valid values: v1beta1, v1
+	// Required (unless the deprecated subjectAccessReviewVersion is set instead), no default
+	// value.
+	// +optional
+	SubjectAccessReviewVersions []string `json:"subjectAccessReviewVersions,omitempty"`
 	// Controls the authorization decision when a webhook request fails to
 	// complete or returns a malformed response or errors evaluating
 	// matchConditions.
@@ -89,6 +180,35 @@ type WebhookConfiguration struct {
 	//      - If failurePolicy=Deny, then the webhook rejects the request
 	//      - If failurePolicy=NoOpinion, then the error is ignored and the webhook is skipped
 	MatchConditions []WebhookMatchCondition `json:"matchConditions"`
+
+	// retryBackoff controls the retry backoff used by this webhook's client.
+	// If unset, the apiserver-wide --authorization-webhook-* retry defaults are used instead.
+	// +optional
+	RetryBackoff *WebhookBackoffConfiguration `json:"retryBackoff,omitempty"`
+
+	// maxInFlight bounds the number of concurrent requests this webhook will have outstanding
+	// at once. Requests beyond the cap fail fast rather than queue, so a slow or degraded
+	// webhook cannot exhaust all apiserver goroutines.
+	// If unset or zero, there is no cap.
+	// +optional
+	MaxInFlight int32 `json:"maxInFlight,omitempty"`
+}
+
+// WebhookBackoffConfiguration mirrors k8s.io/apimachinery/pkg/util/wait.Backoff so it can be set
+// per-webhook instead of once for the whole process.
+type WebhookBackoffConfiguration struct {
+	// duration is the initial amount of time to sleep before the first retry.
+	Duration metav1.Duration `json:"duration"`
+	// factor multiplies duration after each retry.
+	Factor float64 `json:"factor"`
+	// jitter, if non-zero, adds a random fraction of duration to the sleep between retries.
+	// +optional
+	Jitter float64 `json:"jitter,omitempty"`
+	// steps is the number of retry attempts, including the initial one.
+	Steps int32 `json:"steps"`
+	// cap, if non-zero, is the maximum amount of time to sleep between retries.
+	// +optional
+	Cap metav1.Duration `json:"cap,omitempty"`
 }
 
 type WebhookConnectionInfo struct {
@@ -0,0 +1,221 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/ext"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	authorizationapi "k8s.io/apiserver/pkg/authorization/config"
+)
+
+// requestVariableName is the CEL variable a webhook matchCondition or CEL policy rule expression
+// evaluates the SubjectAccessReview under test against, e.g.
+// "request.resourceAttributes.namespace".
+const requestVariableName = "request"
+
+// Request mirrors the parts of authorizationv1.SubjectAccessReviewSpec that matchCondition and CEL
+// policy rule expressions are allowed to inspect. It's registered with the CEL environment via
+// ext.NativeTypes so expressions get real field types and typos are rejected at Check time,
+// without requiring the request variable's full schema to be reflected in from the API types.
+//
+// The same Request type is used to type-check matchConditions and CEL policy rules regardless of
+// which authorization.k8s.io SubjectAccessReview version (v1 or v1beta1) a webhook negotiates:
+// the two versions' SubjectAccessReviewSpec are structurally identical, so there's no per-version
+// type-checking to do. Real per-version schema checking, e.g. against a field added to v1 only,
+// would require registering the apiserver's declarative-validation CEL type provider against the
+// actual versioned type, which isn't wired up here.
+type Request struct {
+	User   string              `cel:"user"`
+	Groups []string            `cel:"groups"`
+	UID    string              `cel:"uid"`
+	Extra  map[string][]string `cel:"extra"`
+
+	ResourceAttributes    *ResourceAttributes    `cel:"resourceAttributes"`
+	NonResourceAttributes *NonResourceAttributes `cel:"nonResourceAttributes"`
+}
+
+// ResourceAttributes mirrors authorizationv1.ResourceAttributes.
+type ResourceAttributes struct {
+	Namespace   string `cel:"namespace"`
+	Verb        string `cel:"verb"`
+	Group       string `cel:"group"`
+	Version     string `cel:"version"`
+	Resource    string `cel:"resource"`
+	Subresource string `cel:"subresource"`
+	Name        string `cel:"name"`
+}
+
+// NonResourceAttributes mirrors authorizationv1.NonResourceAttributes.
+type NonResourceAttributes struct {
+	Path string `cel:"path"`
+	Verb string `cel:"verb"`
+}
+
+// maxMatchConditionCostBudget bounds the runtime cost of evaluating a single matchCondition or CEL
+// policy rule expression: an evaluation that exceeds it stops with an error. The cost is not
+// estimated at compile time, so validation does not reject an expression for being expensive.
+const maxMatchConditionCostBudget = 1000000
+
+// compiledExpression is a parsed and checked matchCondition or CEL policy rule expression, ready
+// to be run against a concrete request by the webhook or CEL authorizer.
+type compiledExpression struct {
+	Program cel.Program
+}
+
+var (
+	expressionCacheMu sync.RWMutex
+	expressionCache   = map[string]*compiledExpression{}
+)
+
+// compileAndCacheMatchCondition type-checks expression against a CEL environment in which
+// requestVariableName is bound to Request, requiring the expression to evaluate to bool, and
+// caches the compiled program so it isn't re-parsed on every call with the same expression.
+func compileAndCacheMatchCondition(fldPath *field.Path, expression string) (*compiledExpression, field.ErrorList) {
+	return compileAndCacheExpression(fldPath, cel.BoolType, expression)
+}
+
+// compileAndCacheCELPolicyRule type-checks expression against the same CEL environment as a
+// matchCondition, except the expression must evaluate to string: a CELPolicyRule's expression
+// returns one of "allow", "deny" or "no-opinion" rather than a plain bool, so the CEL authorizer
+// can tell a rule that denies the request apart from one that merely doesn't match it.
+func compileAndCacheCELPolicyRule(fldPath *field.Path, expression string) (*compiledExpression, field.ErrorList) {
+	return compileAndCacheExpression(fldPath, cel.StringType, expression)
+}
+
+// compileAndCacheExpression type-checks expression against the Request CEL environment, requiring
+// its output type to be want, and caches the compiled program. The cache is keyed on both want and
+// the expression text, since the same expression text could otherwise collide between a
+// bool-returning matchCondition and a string-returning CEL policy rule.
+func compileAndCacheExpression(fldPath *field.Path, want *cel.Type, expression string) (*compiledExpression, field.ErrorList) {
+	cacheKey := want.String() + ":" + expression
+
+	expressionCacheMu.RLock()
+	compiled, ok := expressionCache[cacheKey]
+	expressionCacheMu.RUnlock()
+	if ok {
+		return compiled, nil
+	}
+
+	allErrs := field.ErrorList{}
+
+	env, err := matchConditionEnvironment()
+	if err != nil {
+		allErrs = append(allErrs, field.InternalError(fldPath, fmt.Errorf("building CEL environment: %w", err)))
+		return nil, allErrs
+	}
+
+	ast, issues := env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath, expression, fmt.Sprintf("compilation failed: %s", issues.Err())))
+		return nil, allErrs
+	}
+
+	if outputType := ast.OutputType(); !outputType.IsExactType(want) {
+		allErrs = append(allErrs, field.Invalid(fldPath, expression, fmt.Sprintf("must evaluate to %s, got %s", want, outputType)))
+		return nil, allErrs
+	}
+
+	program, err := env.Program(ast, cel.CostLimit(maxMatchConditionCostBudget))
+	if err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath, expression, fmt.Sprintf("program construction failed: %s", err)))
+		return nil, allErrs
+	}
+
+	compiled = &compiledExpression{Program: program}
+
+	expressionCacheMu.Lock()
+	expressionCache[cacheKey] = compiled
+	expressionCacheMu.Unlock()
+
+	return compiled, nil
+}
+
+// CompiledCELPolicyRule is a CELPolicyRule together with its compiled, string-returning CEL
+// program, ready to be evaluated by the CEL authorizer against a Request built from the incoming
+// authorizer.Attributes.
+type CompiledCELPolicyRule struct {
+	Rule    authorizationapi.CELPolicyRule
+	Program cel.Program
+}
+
+// CompileCELPolicyRule compiles rule.Expression the same way ValidateCELPolicyRule checks it, and
+// returns the resulting program so the CEL authorizer doesn't have to duplicate that compilation
+// or its cache. It assumes rule has already passed ValidateCELPolicyRule; by the time the
+// authorizer is evaluating requests there's no field.Path left to blame a compile failure on, so
+// errors are returned as a plain error rather than a field.ErrorList.
+func CompileCELPolicyRule(rule authorizationapi.CELPolicyRule) (*CompiledCELPolicyRule, error) {
+	compiled, errs := compileAndCacheCELPolicyRule(field.NewPath("expression"), rule.Expression)
+	if len(errs) > 0 {
+		return nil, errs.ToAggregate()
+	}
+	return &CompiledCELPolicyRule{Rule: rule, Program: compiled.Program}, nil
+}
+
+// matchConditionEnvironment builds the CEL environment matchCondition and CEL policy rule
+// expressions are compiled against, with request typed as Request so a typo like
+// request.resourceAttribute (missing the trailing s) is rejected at Check time instead of
+// silently evaluating to an error at request time, and a request.path(p) helper so non-resource
+// CEL policy rules can match against AlwaysAllowPaths-style path prefixes (a plain path, or one
+// ending in "*") without hand-rolling string comparisons in every rule.
+func matchConditionEnvironment() (*cel.Env, error) {
+	return cel.NewEnv(
+		ext.NativeTypes(reflect.TypeOf(Request{}), reflect.TypeOf(ResourceAttributes{}), reflect.TypeOf(NonResourceAttributes{})),
+		cel.Variable(requestVariableName, cel.ObjectType("validation.Request")),
+		cel.Function("path",
+			cel.MemberOverload("request_path_string",
+				[]*cel.Type{cel.ObjectType("validation.Request"), cel.StringType}, cel.BoolType,
+				cel.BinaryBinding(requestPathMatches),
+			),
+		),
+		cel.HomogeneousAggregateLiterals(),
+		cel.EagerlyValidateDeclarations(true),
+		cel.DefaultUTCTimeZone(true),
+	)
+}
+
+// requestPathMatches implements the request.path(p) CEL helper: it reports whether the request is
+// a non-resource request whose path matches p, where p is either an exact path or, if it ends in
+// "*", a prefix.
+func requestPathMatches(lhs, rhs ref.Val) ref.Val {
+	req, ok := lhs.Value().(Request)
+	if !ok {
+		return types.MaybeNoSuchOverloadErr(lhs)
+	}
+	pattern, ok := rhs.Value().(string)
+	if !ok {
+		return types.MaybeNoSuchOverloadErr(rhs)
+	}
+
+	if req.NonResourceAttributes == nil {
+		return types.False
+	}
+
+	path := req.NonResourceAttributes.Path
+	if strings.HasSuffix(pattern, "*") {
+		return types.Bool(strings.HasPrefix(path, strings.TrimSuffix(pattern, "*")))
+	}
+	return types.Bool(path == pattern)
+}
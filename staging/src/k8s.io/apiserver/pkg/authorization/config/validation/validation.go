@@ -23,18 +23,58 @@ import (
 	"strings"
 	"time"
 
-	v1 "k8s.io/api/authorization/v1"
-	"k8s.io/api/authorization/v1beta1"
-	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	authorizationapi "k8s.io/apiserver/pkg/authorization/config"
 )
 
-// ValidateAuthorizationConfiguration validates a given AuthorizationConfiguration.
+// DefaultKnownTypes is every authorizer type this package knows how to validate. Callers that
+// leave knownTypes unset when calling ValidateAuthorizationConfiguration get this set; callers
+// that only want to allow a subset (e.g. kube-apiserver's --authorization-mode flag choices) pass
+// their own, smaller set instead.
+var DefaultKnownTypes = sets.NewString(
+	string(authorizationapi.TypeWebhook),
+	string(authorizationapi.TypeCEL),
+	string(authorizationapi.TypeNode),
+	string(authorizationapi.TypeRBAC),
+	string(authorizationapi.TypeABAC),
+	string(authorizationapi.TypeAlwaysAllow),
+	string(authorizationapi.TypeAlwaysDeny),
+	string(authorizationapi.TypePath),
+)
+
+// nonResourceVerbs is every verb a Path authorizer's AlwaysAllowNonResourceVerbs may contain,
+// mirroring the resource verbs the RBAC authorizer understands; non-resource URLs have no concept
+// of subresources or names, so the set is smaller than the full RBAC verb list.
+var nonResourceVerbs = sets.NewString("get", "list", "watch", "create", "update", "patch", "delete", "deletecollection")
+
+// DefaultRepeatableTypes is every authorizer type that may appear more than once in
+// AuthorizationConfiguration.Authorizers. Only Webhook is repeatable: every other built-in type is
+// a singleton, since a second Node, RBAC, ABAC, AlwaysAllow or AlwaysDeny entry can't mean anything
+// a first one didn't already.
+var DefaultRepeatableTypes = sets.NewString(string(authorizationapi.TypeWebhook))
+
+// ValidateAuthorizationConfiguration validates a given AuthorizationConfiguration. knownTypes and
+// repeatableTypes default to DefaultKnownTypes and DefaultRepeatableTypes when left unset (nil or
+// empty); callers that want to further restrict which types are allowed, e.g. to the set a
+// particular binary's --authorization-mode flag supports, pass their own narrower sets instead.
 func ValidateAuthorizationConfiguration(fldPath *field.Path, c *authorizationapi.AuthorizationConfiguration, knownTypes sets.String, repeatableTypes sets.String) field.ErrorList {
 	allErrs := field.ErrorList{}
 
+	if knownTypes.Len() == 0 {
+		knownTypes = DefaultKnownTypes
+	}
+	if repeatableTypes.Len() == 0 {
+		repeatableTypes = DefaultRepeatableTypes
+	}
+
+	if len(c.Authorizers) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("authorizers"), "at least one authorization mode must be defined"))
+	}
+
+	// A Name is required of every webhook, not only when more than one is configured: it's also
+	// the label every metric in k8s.io/apiserver/pkg/authorization/metrics is keyed by, and an empty
+	// or repeated name there would make those metrics meaningless.
 	webhooks := 0
 	for _, a := range c.Authorizers {
 		if a.Type == authorizationapi.TypeWebhook {
@@ -68,11 +108,159 @@ func ValidateAuthorizationConfiguration(fldPath *field.Path, c *authorizationapi
 				continue
 			}
 			allErrs = append(allErrs, ValidateWebhookConfiguration(fldPath, a.Webhook, webhooks > 0, seenWebhookNames)...)
+		case authorizationapi.TypeCEL:
+			if a.CEL == nil {
+				allErrs = append(allErrs, field.Required(fldPath.Child("cel"), "required when type=CEL"))
+				continue
+			}
+			allErrs = append(allErrs, ValidateCELConfiguration(fldPath.Child("cel"), a.CEL)...)
+		case authorizationapi.TypeABAC:
+			if a.ABAC == nil {
+				allErrs = append(allErrs, field.Required(fldPath.Child("abac"), "required when type=ABAC"))
+				continue
+			}
+			allErrs = append(allErrs, validateABACConfiguration(fldPath.Child("abac"), a.ABAC)...)
+		case authorizationapi.TypePath:
+			if a.Path == nil {
+				allErrs = append(allErrs, field.Required(fldPath.Child("path"), "required when type=Path"))
+				continue
+			}
+			allErrs = append(allErrs, validatePathConfiguration(fldPath.Child("path"), a.Path)...)
+		case authorizationapi.TypeNode, authorizationapi.TypeRBAC, authorizationapi.TypeAlwaysAllow, authorizationapi.TypeAlwaysDeny:
+			// No per-type configuration to validate: Node and RBAC authorize off of
+			// cluster-sourced objects, and AlwaysAllow/AlwaysDeny are unconditional.
+			if a.Webhook != nil {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("webhook"), "non-null", "may only be specified when type=Webhook"))
+			}
 		default:
 			if a.Webhook != nil {
 				allErrs = append(allErrs, field.Invalid(fldPath.Child("webhook"), "non-null", "may only be specified when type=Webhook"))
 			}
 		}
+
+		if a.Type != authorizationapi.TypeCEL && a.CEL != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("cel"), "non-null", "may only be specified when type=CEL"))
+		}
+		if a.Type != authorizationapi.TypeABAC && a.ABAC != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("abac"), "non-null", "may only be specified when type=ABAC"))
+		}
+		if a.Type != authorizationapi.TypePath && a.Path != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("path"), "non-null", "may only be specified when type=Path"))
+		}
+	}
+
+	allErrs = append(allErrs, validateAlwaysAllowPaths(fldPath.Child("alwaysAllowPaths"), c.AlwaysAllowPaths)...)
+
+	return allErrs
+}
+
+// validateAlwaysAllowPaths checks that each path is rooted and that, if it contains a wildcard,
+// the wildcard is only a trailing "*" (e.g. "/healthz" or "/metrics/*").
+func validateAlwaysAllowPaths(fldPath *field.Path, paths []string) field.ErrorList {
+	allErrs := field.ErrorList{}
+	seen := sets.NewString()
+	for i, path := range paths {
+		fldPath := fldPath.Index(i)
+		if !strings.HasPrefix(path, "/") {
+			allErrs = append(allErrs, field.Invalid(fldPath, path, "must start with '/'"))
+		}
+		if idx := strings.Index(path, "*"); idx != -1 && idx != len(path)-1 {
+			allErrs = append(allErrs, field.Invalid(fldPath, path, "wildcards are only permitted as the trailing character of the path"))
+		}
+		if seen.Has(path) {
+			allErrs = append(allErrs, field.Duplicate(fldPath, path))
+			continue
+		}
+		seen.Insert(path)
+	}
+	return allErrs
+}
+
+// validatePathConfiguration validates a Path authorizer's AlwaysAllowPaths the same way
+// AuthorizationConfiguration.AlwaysAllowPaths is validated, and additionally checks that every
+// entry in AlwaysAllowNonResourceVerbs is one of the recognized non-resource verbs.
+func validatePathConfiguration(fldPath *field.Path, c *authorizationapi.PathConfiguration) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, validateAlwaysAllowPaths(fldPath.Child("alwaysAllowPaths"), c.AlwaysAllowPaths)...)
+
+	seen := sets.NewString()
+	for i, verb := range c.AlwaysAllowNonResourceVerbs {
+		fldPath := fldPath.Child("alwaysAllowNonResourceVerbs").Index(i)
+		if !nonResourceVerbs.Has(verb) {
+			allErrs = append(allErrs, field.NotSupported(fldPath, verb, nonResourceVerbs.List()))
+			continue
+		}
+		if seen.Has(verb) {
+			allErrs = append(allErrs, field.Duplicate(fldPath, verb))
+			continue
+		}
+		seen.Insert(verb)
+	}
+
+	return allErrs
+}
+
+// validateABACConfiguration checks PolicyFile the same way WebhookConfiguration's
+// ConnectionInfo.KubeConfigFile is checked: it must be set, an absolute path, and a file that
+// exists, since the ABAC authorizer reads it once at startup and has no way to report a bad path
+// other than failing to start. It uses Lstat rather than Stat so a symlink is rejected outright,
+// rather than silently validated against whatever it happens to resolve to.
+func validateABACConfiguration(fldPath *field.Path, c *authorizationapi.ABACConfiguration) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(c.PolicyFile) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("policyFile"), ""))
+	} else if !filepath.IsAbs(c.PolicyFile) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("policyFile"), c.PolicyFile, "must be an absolute path"))
+	} else if info, err := os.Lstat(c.PolicyFile); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("policyFile"), c.PolicyFile, fmt.Sprintf("error loading file: %v", err)))
+	} else if info.Mode()&os.ModeSymlink != 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("policyFile"), c.PolicyFile, "must not be a symlink"))
+	} else if !info.Mode().IsRegular() {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("policyFile"), c.PolicyFile, "must be a regular file"))
+	}
+
+	return allErrs
+}
+
+// knownSubjectAccessReviewVersions is every authorization.k8s.io SubjectAccessReview version a
+// webhook's SubjectAccessReviewVersion(s) may name.
+var knownSubjectAccessReviewVersions = []string{"v1", "v1beta1"}
+
+// validateSubjectAccessReviewVersions validates a webhook's version preference, accepting either
+// the deprecated singular field, the ordered plural list, or both (as long as they agree).
+// versions must be non-empty, contain no unknown or repeated entries, and singular, if also set,
+// must be a member of versions.
+func validateSubjectAccessReviewVersions(fldPath *field.Path, version string, versions []string) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	effectiveVersions := versions
+	if len(version) > 0 {
+		if len(versions) == 0 {
+			effectiveVersions = []string{version}
+		} else if !sets.NewString(versions...).Has(version) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("subjectAccessReviewVersion"), version, fmt.Sprintf("conflicts with subjectAccessReviewVersions %v", versions)))
+		}
+	}
+
+	if len(effectiveVersions) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("subjectAccessReviewVersions"), ""))
+		return allErrs
+	}
+
+	seen := sets.NewString()
+	for i, v := range effectiveVersions {
+		fldPath := fldPath.Child("subjectAccessReviewVersions").Index(i)
+		if !sets.NewString(knownSubjectAccessReviewVersions...).Has(v) {
+			allErrs = append(allErrs, field.NotSupported(fldPath, v, knownSubjectAccessReviewVersions))
+			continue
+		}
+		if seen.Has(v) {
+			allErrs = append(allErrs, field.Duplicate(fldPath, v))
+			continue
+		}
+		seen.Insert(v)
 	}
 
 	return allErrs
@@ -105,17 +293,7 @@ func ValidateWebhookConfiguration(fldPath *field.Path, c *authorizationapi.Webho
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("timeout"), c.Timeout.Duration.String(), "must be <= 30s"))
 	}
 
-	var sampleSAR runtime.Object
-	switch c.SubjectAccessReviewVersion {
-	case "":
-		allErrs = append(allErrs, field.Required(fldPath.Child("subjectAccessReviewVersion"), ""))
-	case "v1":
-		sampleSAR = &v1.SubjectAccessReview{}
-	case "v1beta1":
-		sampleSAR = &v1beta1.SubjectAccessReview{}
-	default:
-		allErrs = append(allErrs, field.NotSupported(fldPath.Child("subjectAccessReviewVersion"), c.SubjectAccessReviewVersion, []string{"v1", "v1beta1"}))
-	}
+	allErrs = append(allErrs, validateSubjectAccessReviewVersions(fldPath, c.SubjectAccessReviewVersion, c.SubjectAccessReviewVersions)...)
 
 	switch c.FailurePolicy {
 	case "":
@@ -146,20 +324,106 @@ func ValidateWebhookConfiguration(fldPath *field.Path, c *authorizationapi.Webho
 		allErrs = append(allErrs, field.NotSupported(fldPath.Child("connectionInfo", "type"), c.FailurePolicy, []string{"InClusterConfig", "KubeConfigFile"}))
 	}
 
+	seenMatchConditions := sets.NewString()
 	for i, condition := range c.MatchConditions {
 		fldPath := fldPath.Child("matchConditions").Index(i).Child("expression")
-		if len(strings.TrimSpace(condition.Expression)) == 0 {
-			allErrs = append(allErrs, field.Required(fldPath, ""))
-		} else {
-			allErrs = append(allErrs, ValidateWebhookMatchCondition(fldPath, sampleSAR, condition.Expression)...)
-		}
+		allErrs = append(allErrs, validateMatchCondition(fldPath, condition.Expression, seenMatchConditions)...)
+	}
+
+	if c.RetryBackoff != nil {
+		allErrs = append(allErrs, validateWebhookBackoff(fldPath.Child("retryBackoff"), c.RetryBackoff)...)
+	}
+
+	if c.MaxInFlight < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxInFlight"), c.MaxInFlight, "must be greater than or equal to 0"))
+	}
+
+	return allErrs
+}
+
+// validateWebhookBackoff checks that a per-webhook retry backoff override falls within sane
+// ranges, mirroring the constraints the apiserver-wide --authorization-webhook-* flags already
+// enforce via wait.Backoff.
+func validateWebhookBackoff(fldPath *field.Path, b *authorizationapi.WebhookBackoffConfiguration) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if b.Duration.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("duration"), b.Duration.Duration.String(), "must be greater than 0"))
+	}
+	if b.Factor < 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("factor"), b.Factor, "must be greater than or equal to 1"))
+	}
+	if b.Jitter < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("jitter"), b.Jitter, "must be greater than or equal to 0"))
+	}
+	if b.Steps <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("steps"), b.Steps, "must be greater than 0"))
+	}
+	if b.Cap.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("cap"), b.Cap.Duration.String(), "must be greater than or equal to 0"))
 	}
 
 	return allErrs
 }
 
-func ValidateWebhookMatchCondition(fldPath *field.Path, sampleSAR runtime.Object, expression string) field.ErrorList {
+// validateMatchCondition validates a single matchCondition expression: it must be non-empty,
+// type-check against Request, and not repeat an expression already seen earlier in the same
+// webhook's matchConditions list. seen accumulates expressions across calls for one webhook's
+// worth of matchConditions; pass a fresh set per webhook.
+func validateMatchCondition(fldPath *field.Path, expression string, seen sets.String) field.ErrorList {
 	allErrs := field.ErrorList{}
-	// TODO: typecheck CEL expression
+
+	if len(strings.TrimSpace(expression)) == 0 {
+		return append(allErrs, field.Required(fldPath, ""))
+	}
+
+	if seen.Has(expression) {
+		allErrs = append(allErrs, field.Duplicate(fldPath, expression))
+	}
+	seen.Insert(expression)
+
+	return append(allErrs, ValidateWebhookMatchCondition(fldPath, expression)...)
+}
+
+// ValidateWebhookMatchCondition type-checks expression against the Request CEL environment: it
+// must parse and must evaluate to bool. The compiled program stops any evaluation whose runtime
+// cost exceeds the match condition cost budget; that cost is not checked here. The compiled
+// program is cached so the authorizer runtime that evaluates matchConditions per request
+// doesn't have to re-parse the expression.
+func ValidateWebhookMatchCondition(fldPath *field.Path, expression string) field.ErrorList {
+	_, errs := compileAndCacheMatchCondition(fldPath, expression)
+	return errs
+}
+
+// ValidateCELConfiguration validates a CELConfiguration, requiring at least one rule with a
+// non-empty expression.
+func ValidateCELConfiguration(fldPath *field.Path, c *authorizationapi.CELConfiguration) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(c.Rules) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("rules"), "at least one rule must be defined"))
+	}
+
+	for i, rule := range c.Rules {
+		allErrs = append(allErrs, ValidateCELPolicyRule(fldPath.Child("rules").Index(i), rule)...)
+	}
+
+	return allErrs
+}
+
+// ValidateCELPolicyRule validates a single CELPolicyRule. Unlike a matchCondition, which only
+// gates whether a webhook is consulted, a CEL policy rule's expression is itself the authorization
+// decision, so it must evaluate to a string rather than bool. Which string it returns is not
+// checked here: the CEL authorizer rejects anything other than "allow", "deny" or "no-opinion"
+// when it evaluates the rule.
+func ValidateCELPolicyRule(fldPath *field.Path, rule authorizationapi.CELPolicyRule) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(strings.TrimSpace(rule.Expression)) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("expression"), ""))
+	} else if _, errs := compileAndCacheCELPolicyRule(fldPath.Child("expression"), rule.Expression); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
 	return allErrs
 }
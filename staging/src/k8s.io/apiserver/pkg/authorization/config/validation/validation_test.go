@@ -47,6 +47,21 @@ func TestValidateAuthorizationConfiguration(t *testing.T) {
 	tempKubeConfigFilePath := tempKubeConfigFile.Name()
 	defer os.Remove(tempKubeConfigFilePath)
 
+	badPolicyFile := "../some/relative/path/policy.jsonl"
+
+	tempPolicyFile, err := os.CreateTemp("/tmp", "policy")
+	if err != nil {
+		t.Fatalf("failed to set up temp file: %v", err)
+	}
+	tempPolicyFilePath := tempPolicyFile.Name()
+	defer os.Remove(tempPolicyFilePath)
+
+	symlinkPolicyFilePath := tempPolicyFilePath + ".symlink"
+	if err := os.Symlink(tempPolicyFilePath, symlinkPolicyFilePath); err != nil {
+		t.Fatalf("failed to set up symlink: %v", err)
+	}
+	defer os.Remove(symlinkPolicyFilePath)
+
 	tests := []test{
 		// atleast one authorizer should be defined
 		{
@@ -340,7 +355,7 @@ func TestValidateAuthorizationConfiguration(t *testing.T) {
 					},
 				},
 			},
-			expectedErrList: field.ErrorList{field.Required(field.NewPath("subjectAccessReviewVersion"), "")},
+			expectedErrList: field.ErrorList{field.Required(field.NewPath("subjectAccessReviewVersions"), "")},
 			knownTypes:      sets.New[string]("Webhook"),
 			repeatableTypes: sets.New[string]("Webhook"),
 		},
@@ -362,7 +377,107 @@ func TestValidateAuthorizationConfiguration(t *testing.T) {
 					},
 				},
 			},
-			expectedErrList: field.ErrorList{field.NotSupported(field.NewPath("subjectAccessReviewVersion"), "v2beta1", []string{"v1", "v1beta1"})},
+			expectedErrList: field.ErrorList{field.NotSupported(field.NewPath("subjectAccessReviewVersions").Index(0), "v2beta1", []string{"v1", "v1beta1"})},
+			knownTypes:      sets.New[string]("Webhook"),
+			repeatableTypes: sets.New[string]("Webhook"),
+		},
+		// SubjectAccessReviewVersions rejects an empty list
+		{
+			configuration: api.AuthorizationConfiguration{
+				Authorizers: []api.AuthorizerConfiguration{
+					{
+						Type: "Webhook",
+						Webhook: &api.WebhookConfiguration{
+							Name:                        "default",
+							AuthorizedTTL:               metav1.Duration{Duration: 5 * time.Minute},
+							UnauthorizedTTL:             metav1.Duration{Duration: 30 * time.Second},
+							Timeout:                     metav1.Duration{Duration: 5 * time.Second},
+							FailurePolicy:               "NoOpinion",
+							SubjectAccessReviewVersions: []string{},
+							ConnectionInfo: api.WebhookConnectionInfo{
+								Type: "InClusterConfig",
+							},
+						},
+					},
+				},
+			},
+			expectedErrList: field.ErrorList{field.Required(field.NewPath("subjectAccessReviewVersions"), "")},
+			knownTypes:      sets.New[string]("Webhook"),
+			repeatableTypes: sets.New[string]("Webhook"),
+		},
+		// SubjectAccessReviewVersions rejects an unknown version and a duplicate
+		{
+			configuration: api.AuthorizationConfiguration{
+				Authorizers: []api.AuthorizerConfiguration{
+					{
+						Type: "Webhook",
+						Webhook: &api.WebhookConfiguration{
+							Name:                        "default",
+							AuthorizedTTL:               metav1.Duration{Duration: 5 * time.Minute},
+							UnauthorizedTTL:             metav1.Duration{Duration: 30 * time.Second},
+							Timeout:                     metav1.Duration{Duration: 5 * time.Second},
+							FailurePolicy:               "NoOpinion",
+							SubjectAccessReviewVersions: []string{"v1", "v2beta1", "v1"},
+							ConnectionInfo: api.WebhookConnectionInfo{
+								Type: "InClusterConfig",
+							},
+						},
+					},
+				},
+			},
+			expectedErrList: field.ErrorList{
+				field.NotSupported(field.NewPath("subjectAccessReviewVersions").Index(1), "v2beta1", []string{"v1", "v1beta1"}),
+				field.Duplicate(field.NewPath("subjectAccessReviewVersions").Index(2), "v1"),
+			},
+			knownTypes:      sets.New[string]("Webhook"),
+			repeatableTypes: sets.New[string]("Webhook"),
+		},
+		// SubjectAccessReviewVersion, if also set, must be a member of SubjectAccessReviewVersions
+		{
+			configuration: api.AuthorizationConfiguration{
+				Authorizers: []api.AuthorizerConfiguration{
+					{
+						Type: "Webhook",
+						Webhook: &api.WebhookConfiguration{
+							Name:                        "default",
+							AuthorizedTTL:               metav1.Duration{Duration: 5 * time.Minute},
+							UnauthorizedTTL:             metav1.Duration{Duration: 30 * time.Second},
+							Timeout:                     metav1.Duration{Duration: 5 * time.Second},
+							FailurePolicy:               "NoOpinion",
+							SubjectAccessReviewVersion:  "v1beta1",
+							SubjectAccessReviewVersions: []string{"v1"},
+							ConnectionInfo: api.WebhookConnectionInfo{
+								Type: "InClusterConfig",
+							},
+						},
+					},
+				},
+			},
+			expectedErrList: field.ErrorList{field.Invalid(field.NewPath("subjectAccessReviewVersion"), "v1beta1", "")},
+			knownTypes:      sets.New[string]("Webhook"),
+			repeatableTypes: sets.New[string]("Webhook"),
+		},
+		// a valid ordered SubjectAccessReviewVersions list talks to both old and new webhooks
+		{
+			configuration: api.AuthorizationConfiguration{
+				Authorizers: []api.AuthorizerConfiguration{
+					{
+						Type: "Webhook",
+						Webhook: &api.WebhookConfiguration{
+							Name:                        "default",
+							AuthorizedTTL:               metav1.Duration{Duration: 5 * time.Minute},
+							UnauthorizedTTL:             metav1.Duration{Duration: 30 * time.Second},
+							Timeout:                     metav1.Duration{Duration: 5 * time.Second},
+							FailurePolicy:               "NoOpinion",
+							SubjectAccessReviewVersions: []string{"v1", "v1beta1"},
+							ConnectionInfo: api.WebhookConnectionInfo{
+								Type: "InClusterConfig",
+							},
+						},
+					},
+				},
+			},
+			expectedErrList: field.ErrorList{},
 			knownTypes:      sets.New[string]("Webhook"),
 			repeatableTypes: sets.New[string]("Webhook"),
 		},
@@ -570,8 +685,661 @@ func TestValidateAuthorizationConfiguration(t *testing.T) {
 			knownTypes:      sets.New[string]("Webhook"),
 			repeatableTypes: sets.New[string]("Webhook"),
 		},
+		// a matchCondition expression must parse
+		{
+			configuration: api.AuthorizationConfiguration{
+				Authorizers: []api.AuthorizerConfiguration{
+					{
+						Type: "Webhook",
+						Webhook: &api.WebhookConfiguration{
+							Name:                       "default",
+							AuthorizedTTL:              metav1.Duration{Duration: 5 * time.Minute},
+							UnauthorizedTTL:            metav1.Duration{Duration: 30 * time.Second},
+							Timeout:                    metav1.Duration{Duration: 5 * time.Second},
+							FailurePolicy:              "NoOpinion",
+							SubjectAccessReviewVersion: "v1",
+							ConnectionInfo: api.WebhookConnectionInfo{
+								Type: "InClusterConfig",
+							},
+							MatchConditions: []api.WebhookMatchCondition{
+								{Expression: "request.user =="},
+							},
+						},
+					},
+				},
+			},
+			expectedErrList: field.ErrorList{field.Invalid(field.NewPath("expression"), "request.user ==", "")},
+			knownTypes:      sets.New[string]("Webhook"),
+			repeatableTypes: sets.New[string]("Webhook"),
+		},
+		// a matchCondition expression referencing an unknown field is rejected
+		{
+			configuration: api.AuthorizationConfiguration{
+				Authorizers: []api.AuthorizerConfiguration{
+					{
+						Type: "Webhook",
+						Webhook: &api.WebhookConfiguration{
+							Name:                       "default",
+							AuthorizedTTL:              metav1.Duration{Duration: 5 * time.Minute},
+							UnauthorizedTTL:            metav1.Duration{Duration: 30 * time.Second},
+							Timeout:                    metav1.Duration{Duration: 5 * time.Second},
+							FailurePolicy:              "NoOpinion",
+							SubjectAccessReviewVersion: "v1",
+							ConnectionInfo: api.WebhookConnectionInfo{
+								Type: "InClusterConfig",
+							},
+							MatchConditions: []api.WebhookMatchCondition{
+								{Expression: "request.nonexistentField == 'foo'"},
+							},
+						},
+					},
+				},
+			},
+			expectedErrList: field.ErrorList{field.Invalid(field.NewPath("expression"), "request.nonexistentField == 'foo'", "")},
+			knownTypes:      sets.New[string]("Webhook"),
+			repeatableTypes: sets.New[string]("Webhook"),
+		},
+		// a matchCondition expression must evaluate to bool
+		{
+			configuration: api.AuthorizationConfiguration{
+				Authorizers: []api.AuthorizerConfiguration{
+					{
+						Type: "Webhook",
+						Webhook: &api.WebhookConfiguration{
+							Name:                       "default",
+							AuthorizedTTL:              metav1.Duration{Duration: 5 * time.Minute},
+							UnauthorizedTTL:            metav1.Duration{Duration: 30 * time.Second},
+							Timeout:                    metav1.Duration{Duration: 5 * time.Second},
+							FailurePolicy:              "NoOpinion",
+							SubjectAccessReviewVersion: "v1",
+							ConnectionInfo: api.WebhookConnectionInfo{
+								Type: "InClusterConfig",
+							},
+							MatchConditions: []api.WebhookMatchCondition{
+								{Expression: "request.user"},
+							},
+						},
+					},
+				},
+			},
+			expectedErrList: field.ErrorList{field.Invalid(field.NewPath("expression"), "request.user", "")},
+			knownTypes:      sets.New[string]("Webhook"),
+			repeatableTypes: sets.New[string]("Webhook"),
+		},
+		// matchCondition expressions within a webhook must be unique
+		{
+			configuration: api.AuthorizationConfiguration{
+				Authorizers: []api.AuthorizerConfiguration{
+					{
+						Type: "Webhook",
+						Webhook: &api.WebhookConfiguration{
+							Name:                       "default",
+							AuthorizedTTL:              metav1.Duration{Duration: 5 * time.Minute},
+							UnauthorizedTTL:            metav1.Duration{Duration: 30 * time.Second},
+							Timeout:                    metav1.Duration{Duration: 5 * time.Second},
+							FailurePolicy:              "NoOpinion",
+							SubjectAccessReviewVersion: "v1",
+							ConnectionInfo: api.WebhookConnectionInfo{
+								Type: "InClusterConfig",
+							},
+							MatchConditions: []api.WebhookMatchCondition{
+								{Expression: "request.resourceAttributes.namespace == 'kube-system'"},
+								{Expression: "request.resourceAttributes.namespace == 'kube-system'"},
+							},
+						},
+					},
+				},
+			},
+			expectedErrList: field.ErrorList{field.Duplicate(field.NewPath("expression"), "request.resourceAttributes.namespace == 'kube-system'")},
+			knownTypes:      sets.New[string]("Webhook"),
+			repeatableTypes: sets.New[string]("Webhook"),
+		},
+		// a valid matchCondition expression inspecting request.resourceAttributes.namespace
+		{
+			configuration: api.AuthorizationConfiguration{
+				Authorizers: []api.AuthorizerConfiguration{
+					{
+						Type: "Webhook",
+						Webhook: &api.WebhookConfiguration{
+							Name:                       "default",
+							AuthorizedTTL:              metav1.Duration{Duration: 5 * time.Minute},
+							UnauthorizedTTL:            metav1.Duration{Duration: 30 * time.Second},
+							Timeout:                    metav1.Duration{Duration: 5 * time.Second},
+							FailurePolicy:              "NoOpinion",
+							SubjectAccessReviewVersion: "v1",
+							ConnectionInfo: api.WebhookConnectionInfo{
+								Type: "InClusterConfig",
+							},
+							MatchConditions: []api.WebhookMatchCondition{
+								{Expression: "request.resourceAttributes.namespace == 'kube-system'"},
+							},
+						},
+					},
+				},
+			},
+			expectedErrList: field.ErrorList{},
+			knownTypes:      sets.New[string]("Webhook"),
+			repeatableTypes: sets.New[string]("Webhook"),
+		},
 
-		// TODO: When the CEL expression validator is implemented, add a few test cases to typecheck the expression
+		// a webhook's retryBackoff duration must be greater than 0
+		{
+			configuration: api.AuthorizationConfiguration{
+				Authorizers: []api.AuthorizerConfiguration{
+					{
+						Type: "Webhook",
+						Webhook: &api.WebhookConfiguration{
+							Name:                       "default",
+							AuthorizedTTL:              metav1.Duration{Duration: 5 * time.Minute},
+							UnauthorizedTTL:            metav1.Duration{Duration: 30 * time.Second},
+							Timeout:                    metav1.Duration{Duration: 5 * time.Second},
+							FailurePolicy:              "NoOpinion",
+							SubjectAccessReviewVersion: "v1",
+							ConnectionInfo: api.WebhookConnectionInfo{
+								Type: "InClusterConfig",
+							},
+							RetryBackoff: &api.WebhookBackoffConfiguration{
+								Duration: metav1.Duration{Duration: 0},
+								Factor:   1.5,
+								Jitter:   0.1,
+								Steps:    5,
+								Cap:      metav1.Duration{Duration: time.Second},
+							},
+						},
+					},
+				},
+			},
+			expectedErrList: field.ErrorList{field.Invalid(field.NewPath("retryBackoff", "duration"), "0s", "")},
+			knownTypes:      sets.New[string]("Webhook"),
+			repeatableTypes: sets.New[string]("Webhook"),
+		},
+		// a webhook's retryBackoff factor must be greater than or equal to 1
+		{
+			configuration: api.AuthorizationConfiguration{
+				Authorizers: []api.AuthorizerConfiguration{
+					{
+						Type: "Webhook",
+						Webhook: &api.WebhookConfiguration{
+							Name:                       "default",
+							AuthorizedTTL:              metav1.Duration{Duration: 5 * time.Minute},
+							UnauthorizedTTL:            metav1.Duration{Duration: 30 * time.Second},
+							Timeout:                    metav1.Duration{Duration: 5 * time.Second},
+							FailurePolicy:              "NoOpinion",
+							SubjectAccessReviewVersion: "v1",
+							ConnectionInfo: api.WebhookConnectionInfo{
+								Type: "InClusterConfig",
+							},
+							RetryBackoff: &api.WebhookBackoffConfiguration{
+								Duration: metav1.Duration{Duration: time.Second},
+								Factor:   0.5,
+								Jitter:   0.1,
+								Steps:    5,
+								Cap:      metav1.Duration{Duration: time.Second},
+							},
+						},
+					},
+				},
+			},
+			expectedErrList: field.ErrorList{field.Invalid(field.NewPath("retryBackoff", "factor"), 0.5, "")},
+			knownTypes:      sets.New[string]("Webhook"),
+			repeatableTypes: sets.New[string]("Webhook"),
+		},
+		// a webhook's retryBackoff jitter must be greater than or equal to 0
+		{
+			configuration: api.AuthorizationConfiguration{
+				Authorizers: []api.AuthorizerConfiguration{
+					{
+						Type: "Webhook",
+						Webhook: &api.WebhookConfiguration{
+							Name:                       "default",
+							AuthorizedTTL:              metav1.Duration{Duration: 5 * time.Minute},
+							UnauthorizedTTL:            metav1.Duration{Duration: 30 * time.Second},
+							Timeout:                    metav1.Duration{Duration: 5 * time.Second},
+							FailurePolicy:              "NoOpinion",
+							SubjectAccessReviewVersion: "v1",
+							ConnectionInfo: api.WebhookConnectionInfo{
+								Type: "InClusterConfig",
+							},
+							RetryBackoff: &api.WebhookBackoffConfiguration{
+								Duration: metav1.Duration{Duration: time.Second},
+								Factor:   1.5,
+								Jitter:   -0.1,
+								Steps:    5,
+								Cap:      metav1.Duration{Duration: time.Second},
+							},
+						},
+					},
+				},
+			},
+			expectedErrList: field.ErrorList{field.Invalid(field.NewPath("retryBackoff", "jitter"), -0.1, "")},
+			knownTypes:      sets.New[string]("Webhook"),
+			repeatableTypes: sets.New[string]("Webhook"),
+		},
+		// a webhook's retryBackoff steps must be greater than 0
+		{
+			configuration: api.AuthorizationConfiguration{
+				Authorizers: []api.AuthorizerConfiguration{
+					{
+						Type: "Webhook",
+						Webhook: &api.WebhookConfiguration{
+							Name:                       "default",
+							AuthorizedTTL:              metav1.Duration{Duration: 5 * time.Minute},
+							UnauthorizedTTL:            metav1.Duration{Duration: 30 * time.Second},
+							Timeout:                    metav1.Duration{Duration: 5 * time.Second},
+							FailurePolicy:              "NoOpinion",
+							SubjectAccessReviewVersion: "v1",
+							ConnectionInfo: api.WebhookConnectionInfo{
+								Type: "InClusterConfig",
+							},
+							RetryBackoff: &api.WebhookBackoffConfiguration{
+								Duration: metav1.Duration{Duration: time.Second},
+								Factor:   1.5,
+								Jitter:   0.1,
+								Steps:    0,
+								Cap:      metav1.Duration{Duration: time.Second},
+							},
+						},
+					},
+				},
+			},
+			expectedErrList: field.ErrorList{field.Invalid(field.NewPath("retryBackoff", "steps"), int32(0), "")},
+			knownTypes:      sets.New[string]("Webhook"),
+			repeatableTypes: sets.New[string]("Webhook"),
+		},
+		// a webhook's retryBackoff cap must be greater than or equal to 0
+		{
+			configuration: api.AuthorizationConfiguration{
+				Authorizers: []api.AuthorizerConfiguration{
+					{
+						Type: "Webhook",
+						Webhook: &api.WebhookConfiguration{
+							Name:                       "default",
+							AuthorizedTTL:              metav1.Duration{Duration: 5 * time.Minute},
+							UnauthorizedTTL:            metav1.Duration{Duration: 30 * time.Second},
+							Timeout:                    metav1.Duration{Duration: 5 * time.Second},
+							FailurePolicy:              "NoOpinion",
+							SubjectAccessReviewVersion: "v1",
+							ConnectionInfo: api.WebhookConnectionInfo{
+								Type: "InClusterConfig",
+							},
+							RetryBackoff: &api.WebhookBackoffConfiguration{
+								Duration: metav1.Duration{Duration: time.Second},
+								Factor:   1.5,
+								Jitter:   0.1,
+								Steps:    5,
+								Cap:      metav1.Duration{Duration: -time.Second},
+							},
+						},
+					},
+				},
+			},
+			expectedErrList: field.ErrorList{field.Invalid(field.NewPath("retryBackoff", "cap"), "-1s", "")},
+			knownTypes:      sets.New[string]("Webhook"),
+			repeatableTypes: sets.New[string]("Webhook"),
+		},
+		// a webhook's maxInFlight must be greater than or equal to 0
+		{
+			configuration: api.AuthorizationConfiguration{
+				Authorizers: []api.AuthorizerConfiguration{
+					{
+						Type: "Webhook",
+						Webhook: &api.WebhookConfiguration{
+							Name:                       "default",
+							AuthorizedTTL:              metav1.Duration{Duration: 5 * time.Minute},
+							UnauthorizedTTL:            metav1.Duration{Duration: 30 * time.Second},
+							Timeout:                    metav1.Duration{Duration: 5 * time.Second},
+							FailurePolicy:              "NoOpinion",
+							SubjectAccessReviewVersion: "v1",
+							ConnectionInfo: api.WebhookConnectionInfo{
+								Type: "InClusterConfig",
+							},
+							MaxInFlight: -1,
+						},
+					},
+				},
+			},
+			expectedErrList: field.ErrorList{field.Invalid(field.NewPath("maxInFlight"), int32(-1), "")},
+			knownTypes:      sets.New[string]("Webhook"),
+			repeatableTypes: sets.New[string]("Webhook"),
+		},
+
+		// alwaysAllowPaths entries must start with "/" and may only wildcard the trailing segment
+		{
+			configuration: api.AuthorizationConfiguration{
+				Authorizers: []api.AuthorizerConfiguration{
+					{
+						Type: "Webhook",
+						Webhook: &api.WebhookConfiguration{
+							Name:                       "default",
+							AuthorizedTTL:              metav1.Duration{Duration: 5 * time.Minute},
+							UnauthorizedTTL:            metav1.Duration{Duration: 30 * time.Second},
+							Timeout:                    metav1.Duration{Duration: 5 * time.Second},
+							FailurePolicy:              "NoOpinion",
+							SubjectAccessReviewVersion: "v1",
+							ConnectionInfo: api.WebhookConnectionInfo{
+								Type: "InClusterConfig",
+							},
+						},
+					},
+				},
+				AlwaysAllowPaths: []string{"/healthz/*/foo"},
+			},
+			expectedErrList: field.ErrorList{field.Invalid(field.NewPath("alwaysAllowPaths").Index(0), "/healthz/*/foo", "wildcards are only permitted as the trailing character of the path")},
+			knownTypes:      sets.New[string]("Webhook"),
+			repeatableTypes: sets.New[string]("Webhook"),
+		},
+
+		// when type=CEL, cel needs to be defined
+		{
+			configuration: api.AuthorizationConfiguration{
+				Authorizers: []api.AuthorizerConfiguration{
+					{
+						Type: "CEL",
+					},
+				},
+			},
+			expectedErrList: field.ErrorList{field.Required(field.NewPath("cel"), "required when type=CEL")},
+			knownTypes:      sets.New[string]("CEL"),
+			repeatableTypes: sets.New[string](),
+		},
+		// when type=CEL, at least one rule must be defined
+		{
+			configuration: api.AuthorizationConfiguration{
+				Authorizers: []api.AuthorizerConfiguration{
+					{
+						Type: "CEL",
+						CEL:  &api.CELConfiguration{},
+					},
+				},
+			},
+			expectedErrList: field.ErrorList{field.Required(field.NewPath("cel", "rules"), "at least one rule must be defined")},
+			knownTypes:      sets.New[string]("CEL"),
+			repeatableTypes: sets.New[string](),
+		},
+		// a CEL rule's expression should be non-empty
+		{
+			configuration: api.AuthorizationConfiguration{
+				Authorizers: []api.AuthorizerConfiguration{
+					{
+						Type: "CEL",
+						CEL: &api.CELConfiguration{
+							Rules: []api.CELPolicyRule{{Expression: ""}},
+						},
+					},
+				},
+			},
+			expectedErrList: field.ErrorList{field.Required(field.NewPath("cel", "rules").Index(0).Child("expression"), "")},
+			knownTypes:      sets.New[string]("CEL"),
+			repeatableTypes: sets.New[string](),
+		},
+		// a valid CEL authorizer configuration
+		{
+			configuration: api.AuthorizationConfiguration{
+				Authorizers: []api.AuthorizerConfiguration{
+					{
+						Type: "CEL",
+						CEL: &api.CELConfiguration{
+							Rules: []api.CELPolicyRule{{Expression: "request.groups.exists(g, g == 'system:masters') ? 'allow' : 'no-opinion'"}},
+						},
+					},
+				},
+			},
+			expectedErrList: field.ErrorList{},
+			knownTypes:      sets.New[string]("CEL"),
+			repeatableTypes: sets.New[string](),
+		},
+		// cel should only be set when type=CEL
+		{
+			configuration: api.AuthorizationConfiguration{
+				Authorizers: []api.AuthorizerConfiguration{
+					{
+						Type: "Foo",
+						CEL:  &api.CELConfiguration{},
+					},
+				},
+			},
+			expectedErrList: field.ErrorList{field.Invalid(field.NewPath("cel"), "non-null", "may only be specified when type=CEL")},
+			knownTypes:      sets.New[string]("Foo"),
+			repeatableTypes: sets.New[string]("Webhook"),
+		},
+
+		// Node, RBAC, AlwaysAllow, AlwaysDeny need no per-type configuration
+		{
+			configuration: api.AuthorizationConfiguration{
+				Authorizers: []api.AuthorizerConfiguration{
+					{Type: "Node"},
+					{Type: "RBAC"},
+					{Type: "AlwaysAllow"},
+					{Type: "AlwaysDeny"},
+				},
+			},
+			expectedErrList: field.ErrorList{},
+			knownTypes:      sets.New[string]("Node", "RBAC", "AlwaysAllow", "AlwaysDeny"),
+			repeatableTypes: sets.New[string](),
+		},
+		// when type=ABAC, abac needs to be defined
+		{
+			configuration: api.AuthorizationConfiguration{
+				Authorizers: []api.AuthorizerConfiguration{
+					{Type: "ABAC"},
+				},
+			},
+			expectedErrList: field.ErrorList{field.Required(field.NewPath("abac"), "required when type=ABAC")},
+			knownTypes:      sets.New[string]("ABAC"),
+			repeatableTypes: sets.New[string](),
+		},
+		// webhook should only be set when type=Webhook, even for the other built-in types
+		{
+			configuration: api.AuthorizationConfiguration{
+				Authorizers: []api.AuthorizerConfiguration{
+					{
+						Type: "Node",
+						Webhook: &api.WebhookConfiguration{
+							Name: "default",
+						},
+					},
+				},
+			},
+			expectedErrList: field.ErrorList{field.Invalid(field.NewPath("webhook"), "non-null", "may only be specified when type=Webhook")},
+			knownTypes:      sets.New[string]("Node"),
+			repeatableTypes: sets.New[string](),
+		},
+		// knownTypes and repeatableTypes default to every built-in authorizer type, with only
+		// Webhook repeatable, when left unset
+		{
+			configuration: api.AuthorizationConfiguration{
+				Authorizers: []api.AuthorizerConfiguration{
+					{Type: "RBAC"},
+					{Type: "RBAC"},
+				},
+			},
+			expectedErrList: field.ErrorList{field.Duplicate(field.NewPath("authorizers").Index(1).Child("type"), "RBAC")},
+		},
+		// ABAC requires a policy file
+		{
+			configuration: api.AuthorizationConfiguration{
+				Authorizers: []api.AuthorizerConfiguration{
+					{Type: "ABAC", ABAC: &api.ABACConfiguration{}},
+				},
+			},
+			expectedErrList: field.ErrorList{field.Required(field.NewPath("abac", "policyFile"), "")},
+			knownTypes:      sets.New[string]("ABAC"),
+			repeatableTypes: sets.New[string](),
+		},
+		// ABAC's policy file must be an absolute path
+		{
+			configuration: api.AuthorizationConfiguration{
+				Authorizers: []api.AuthorizerConfiguration{
+					{Type: "ABAC", ABAC: &api.ABACConfiguration{PolicyFile: badPolicyFile}},
+				},
+			},
+			expectedErrList: field.ErrorList{field.Invalid(field.NewPath("abac", "policyFile"), badPolicyFile, "must be an absolute path")},
+			knownTypes:      sets.New[string]("ABAC"),
+			repeatableTypes: sets.New[string](),
+		},
+		// ABAC's policy file must exist and be a regular file
+		{
+			configuration: api.AuthorizationConfiguration{
+				Authorizers: []api.AuthorizerConfiguration{
+					{Type: "ABAC", ABAC: &api.ABACConfiguration{PolicyFile: tempPolicyFilePath}},
+				},
+			},
+			expectedErrList: field.ErrorList{},
+			knownTypes:      sets.New[string]("ABAC"),
+			repeatableTypes: sets.New[string](),
+		},
+		// ABAC's policy file must not be a symlink, even one pointing at a regular file
+		{
+			configuration: api.AuthorizationConfiguration{
+				Authorizers: []api.AuthorizerConfiguration{
+					{Type: "ABAC", ABAC: &api.ABACConfiguration{PolicyFile: symlinkPolicyFilePath}},
+				},
+			},
+			expectedErrList: field.ErrorList{field.Invalid(field.NewPath("abac", "policyFile"), symlinkPolicyFilePath, "must not be a symlink")},
+			knownTypes:      sets.New[string]("ABAC"),
+			repeatableTypes: sets.New[string](),
+		},
+		// abac should only be set when type=ABAC
+		{
+			configuration: api.AuthorizationConfiguration{
+				Authorizers: []api.AuthorizerConfiguration{
+					{Type: "Foo", ABAC: &api.ABACConfiguration{}},
+				},
+			},
+			expectedErrList: field.ErrorList{field.Invalid(field.NewPath("abac"), "non-null", "may only be specified when type=ABAC")},
+			knownTypes:      sets.New[string]("Foo"),
+			repeatableTypes: sets.New[string]("Webhook"),
+		},
+
+		// when type=Path, path needs to be defined
+		{
+			configuration: api.AuthorizationConfiguration{
+				Authorizers: []api.AuthorizerConfiguration{
+					{Type: "Path"},
+				},
+			},
+			expectedErrList: field.ErrorList{field.Required(field.NewPath("path"), "required when type=Path")},
+			knownTypes:      sets.New[string]("Path"),
+			repeatableTypes: sets.New[string](),
+		},
+		// path should only be set when type=Path
+		{
+			configuration: api.AuthorizationConfiguration{
+				Authorizers: []api.AuthorizerConfiguration{
+					{Type: "Foo", Path: &api.PathConfiguration{}},
+				},
+			},
+			expectedErrList: field.ErrorList{field.Invalid(field.NewPath("path"), "non-null", "may only be specified when type=Path")},
+			knownTypes:      sets.New[string]("Foo"),
+			repeatableTypes: sets.New[string]("Webhook"),
+		},
+		// a Path authorizer's paths must start with '/', may only glob a trailing '*', and may not repeat
+		{
+			configuration: api.AuthorizationConfiguration{
+				Authorizers: []api.AuthorizerConfiguration{
+					{
+						Type: "Path",
+						Path: &api.PathConfiguration{
+							AlwaysAllowPaths: []string{"healthz", "/metrics/*/extra", "/readyz", "/readyz"},
+						},
+					},
+				},
+			},
+			expectedErrList: field.ErrorList{
+				field.Invalid(field.NewPath("path", "alwaysAllowPaths").Index(0), "healthz", "must start with '/'"),
+				field.Invalid(field.NewPath("path", "alwaysAllowPaths").Index(1), "/metrics/*/extra", "wildcards are only permitted as the trailing character of the path"),
+				field.Duplicate(field.NewPath("path", "alwaysAllowPaths").Index(3), "/readyz"),
+			},
+			knownTypes:      sets.New[string]("Path"),
+			repeatableTypes: sets.New[string](),
+		},
+		// a Path authorizer's AlwaysAllowNonResourceVerbs must be a recognized verb and may not repeat
+		{
+			configuration: api.AuthorizationConfiguration{
+				Authorizers: []api.AuthorizerConfiguration{
+					{
+						Type: "Path",
+						Path: &api.PathConfiguration{
+							AlwaysAllowPaths:            []string{"/metrics/*"},
+							AlwaysAllowNonResourceVerbs: []string{"get", "frobnicate", "get"},
+						},
+					},
+				},
+			},
+			expectedErrList: field.ErrorList{
+				field.NotSupported(field.NewPath("path", "alwaysAllowNonResourceVerbs").Index(1), "frobnicate", []string{}),
+				field.Duplicate(field.NewPath("path", "alwaysAllowNonResourceVerbs").Index(2), "get"),
+			},
+			knownTypes:      sets.New[string]("Path"),
+			repeatableTypes: sets.New[string](),
+		},
+		// a valid Path authorizer configuration
+		{
+			configuration: api.AuthorizationConfiguration{
+				Authorizers: []api.AuthorizerConfiguration{
+					{
+						Type: "Path",
+						Path: &api.PathConfiguration{
+							AlwaysAllowPaths:            []string{"/healthz", "/livez", "/readyz", "/metrics/*"},
+							AlwaysAllowNonResourceVerbs: []string{"get"},
+						},
+					},
+				},
+			},
+			expectedErrList: field.ErrorList{},
+			knownTypes:      sets.New[string]("Path"),
+			repeatableTypes: sets.New[string](),
+		},
+
+		// a CEL rule's expression must parse
+		{
+			configuration: api.AuthorizationConfiguration{
+				Authorizers: []api.AuthorizerConfiguration{
+					{
+						Type: "CEL",
+						CEL: &api.CELConfiguration{
+							Rules: []api.CELPolicyRule{{Expression: "request.user.groups.exists(g,"}},
+						},
+					},
+				},
+			},
+			expectedErrList: field.ErrorList{field.Invalid(field.NewPath("cel", "rules").Index(0).Child("expression"), "request.user.groups.exists(g,", "")},
+			knownTypes:      sets.New[string]("CEL"),
+			repeatableTypes: sets.New[string](),
+		},
+		// a CEL rule's expression must type-check against the request variable's fields
+		{
+			configuration: api.AuthorizationConfiguration{
+				Authorizers: []api.AuthorizerConfiguration{
+					{
+						Type: "CEL",
+						CEL: &api.CELConfiguration{
+							Rules: []api.CELPolicyRule{{Expression: "request.user.username"}},
+						},
+					},
+				},
+			},
+			expectedErrList: field.ErrorList{field.Invalid(field.NewPath("cel", "rules").Index(0).Child("expression"), "request.user.username", "")},
+			knownTypes:      sets.New[string]("CEL"),
+			repeatableTypes: sets.New[string](),
+		},
+
+		// a CEL rule's expression referencing an unknown field is rejected
+		{
+			configuration: api.AuthorizationConfiguration{
+				Authorizers: []api.AuthorizerConfiguration{
+					{
+						Type: "CEL",
+						CEL: &api.CELConfiguration{
+							Rules: []api.CELPolicyRule{{Expression: "request.nonexistentField == 'foo'"}},
+						},
+					},
+				},
+			},
+			expectedErrList: field.ErrorList{field.Invalid(field.NewPath("cel", "rules").Index(0).Child("expression"), "request.nonexistentField == 'foo'", "")},
+			knownTypes:      sets.New[string]("CEL"),
+			repeatableTypes: sets.New[string](),
+		},
 	}
 
 	for _, test := range tests {
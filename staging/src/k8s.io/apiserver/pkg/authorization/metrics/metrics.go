@@ -0,0 +1,148 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics instruments webhook authorizers configured via AuthorizationConfiguration.
+// Every metric is labeled by WebhookConfiguration.Name, the field whose doc comment calls it out
+// as being "used in monitoring machinery for metrics" — callers in the webhook authorizer's
+// Authorize path are expected to call the Record* functions below rather than registering their
+// own metrics, so every webhook authorizer in the chain reports consistently.
+package metrics
+
+import (
+	"time"
+
+	"k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+)
+
+const (
+	namespace = "apiserver"
+	subsystem = "authorization_webhook"
+)
+
+// Decision labels for requestDecisionsTotal.
+const (
+	DecisionAllow     = "allow"
+	DecisionDeny      = "deny"
+	DecisionNoOpinion = "no_opinion"
+)
+
+// TTL bucket labels for cacheTotal: which of the two TTLs (AuthorizedTTL/UnauthorizedTTL) the
+// cache entry being looked up falls under.
+const (
+	TTLBucketAuthorized   = "authorized"
+	TTLBucketUnauthorized = "unauthorized"
+)
+
+// Cache result labels for cacheTotal.
+const (
+	CacheResultHit  = "hit"
+	CacheResultMiss = "miss"
+)
+
+var (
+	requestDuration = metrics.NewHistogramVec(
+		&metrics.HistogramOpts{
+			Namespace:      namespace,
+			Subsystem:      subsystem,
+			Name:           "duration_seconds",
+			Help:           "Request latency in seconds for each webhook authorizer, keyed by its configured name.",
+			Buckets:        metrics.ExponentialBuckets(0.001, 2, 15),
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"name"},
+	)
+
+	requestDecisionsTotal = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Namespace:      namespace,
+			Subsystem:      subsystem,
+			Name:           "decisions_total",
+			Help:           "Total number of decisions made by each webhook authorizer, by decision.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"name", "decision"},
+	)
+
+	failurePolicyTotal = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Namespace:      namespace,
+			Subsystem:      subsystem,
+			Name:           "failure_policy_total",
+			Help:           "Total number of times a webhook authorizer's failurePolicy was applied after the webhook request itself failed (errored, timed out, or its matchConditions errored), by the resulting decision.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"name", "decision"},
+	)
+
+	cacheTotal = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Namespace:      namespace,
+			Subsystem:      subsystem,
+			Name:           "cache_total",
+			Help:           "Total number of lookups against a webhook authorizer's decision cache, by TTL bucket (authorized/unauthorized) and whether the lookup was a hit or a miss.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"name", "ttl_bucket", "result"},
+	)
+
+	matchConditionExclusionsTotal = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Namespace:      namespace,
+			Subsystem:      subsystem,
+			Name:           "match_condition_exclusions_total",
+			Help:           "Total number of requests a webhook authorizer skipped because one of its matchConditions evaluated to false.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"name"},
+	)
+)
+
+func init() {
+	legacyregistry.MustRegister(requestDuration)
+	legacyregistry.MustRegister(requestDecisionsTotal)
+	legacyregistry.MustRegister(failurePolicyTotal)
+	legacyregistry.MustRegister(cacheTotal)
+	legacyregistry.MustRegister(matchConditionExclusionsTotal)
+}
+
+// RecordRequestDuration records how long a call to the webhook named name took.
+func RecordRequestDuration(name string, duration time.Duration) {
+	requestDuration.WithLabelValues(name).Observe(duration.Seconds())
+}
+
+// RecordDecision records the decision a webhook authorizer reached for a request, one of
+// DecisionAllow, DecisionDeny or DecisionNoOpinion.
+func RecordDecision(name, decision string) {
+	requestDecisionsTotal.WithLabelValues(name, decision).Inc()
+}
+
+// RecordFailurePolicy records that a webhook request itself failed and decision was the result of
+// applying the webhook's configured failurePolicy, rather than an opinion from the webhook.
+func RecordFailurePolicy(name, decision string) {
+	failurePolicyTotal.WithLabelValues(name, decision).Inc()
+}
+
+// RecordCacheResult records a lookup against a webhook authorizer's decision cache.
+func RecordCacheResult(name, ttlBucket, result string) {
+	cacheTotal.WithLabelValues(name, ttlBucket, result).Inc()
+}
+
+// RecordMatchConditionExclusion records that a request was skipped by a webhook authorizer
+// because one of its matchConditions evaluated to false.
+func RecordMatchConditionExclusion(name string) {
+	matchConditionExclusionsTotal.WithLabelValues(name).Inc()
+}
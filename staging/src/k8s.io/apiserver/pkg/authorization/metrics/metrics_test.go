@@ -0,0 +1,77 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/component-base/metrics/testutil"
+)
+
+// Every test here uses a webhook name unique to that test, so package-level metric state left
+// over from one test can't be mistaken for another's.
+
+func TestRecordRequestDuration(t *testing.T) {
+	before, err := testutil.CollectAndCount(requestDuration)
+	if err != nil {
+		t.Fatalf("CollectAndCount: %v", err)
+	}
+
+	RecordRequestDuration("test-record-request-duration", 150*time.Millisecond)
+
+	after, err := testutil.CollectAndCount(requestDuration)
+	if err != nil {
+		t.Fatalf("CollectAndCount: %v", err)
+	}
+	if after != before+1 {
+		t.Errorf("expected RecordRequestDuration to add one observed series, got %d series before and %d after", before, after)
+	}
+}
+
+func TestRecordDecision(t *testing.T) {
+	RecordDecision("test-record-decision", DecisionAllow)
+	if got := testutil.ToFloat64(requestDecisionsTotal.WithLabelValues("test-record-decision", DecisionAllow)); got != 1 {
+		t.Errorf("expected 1 after one call, got %v", got)
+	}
+
+	RecordDecision("test-record-decision", DecisionAllow)
+	if got := testutil.ToFloat64(requestDecisionsTotal.WithLabelValues("test-record-decision", DecisionAllow)); got != 2 {
+		t.Errorf("expected 2 after a second call, got %v", got)
+	}
+}
+
+func TestRecordFailurePolicy(t *testing.T) {
+	RecordFailurePolicy("test-record-failure-policy", DecisionDeny)
+	if got := testutil.ToFloat64(failurePolicyTotal.WithLabelValues("test-record-failure-policy", DecisionDeny)); got != 1 {
+		t.Errorf("expected 1, got %v", got)
+	}
+}
+
+func TestRecordCacheResult(t *testing.T) {
+	RecordCacheResult("test-record-cache-result", TTLBucketAuthorized, CacheResultHit)
+	if got := testutil.ToFloat64(cacheTotal.WithLabelValues("test-record-cache-result", TTLBucketAuthorized, CacheResultHit)); got != 1 {
+		t.Errorf("expected 1, got %v", got)
+	}
+}
+
+func TestRecordMatchConditionExclusion(t *testing.T) {
+	RecordMatchConditionExclusion("test-record-match-condition-exclusion")
+	if got := testutil.ToFloat64(matchConditionExclusionsTotal.WithLabelValues("test-record-match-condition-exclusion")); got != 1 {
+		t.Errorf("expected 1, got %v", got)
+	}
+}
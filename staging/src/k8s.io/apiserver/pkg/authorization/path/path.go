@@ -0,0 +1,83 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package path implements an authorizer.Authorizer that allows non-resource requests whose path
+// matches a fixed list of always-allowed paths, the way a PathConfiguration or
+// AuthorizationConfiguration.AlwaysAllowPaths is meant to behave, e.g. so health and readiness
+// probes don't need an RBAC binding.
+package path
+
+import (
+	"context"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+)
+
+// Authorizer allows a non-resource request whose path matches one of a fixed list of paths and
+// whose verb is allowed, and returns DecisionNoOpinion for every other request so the next
+// authorizer in the chain gets a say. A resource request is never allowed by Authorizer,
+// regardless of path, since AlwaysAllowPaths and PathConfiguration are both documented as acting
+// on HTTP paths, not API resources.
+type Authorizer struct {
+	paths []string
+	// verbs is every non-resource verb this Authorizer allows; nil means every verb is allowed,
+	// matching AuthorizationConfiguration.AlwaysAllowPaths, which isn't restricted by verb.
+	verbs sets.String
+}
+
+// NewAuthorizer returns an Authorizer that allows a non-resource request whose path exactly
+// matches one of paths, or, for an entry ending in "*", has that entry (minus the "*") as a
+// prefix. If verbs is non-empty, the request's verb must also be in verbs; an empty verbs allows
+// any verb.
+func NewAuthorizer(paths []string, verbs []string) *Authorizer {
+	a := &Authorizer{paths: paths}
+	if len(verbs) > 0 {
+		a.verbs = sets.NewString(verbs...)
+	}
+	return a
+}
+
+// Authorize implements authorizer.Authorizer.
+func (a *Authorizer) Authorize(_ context.Context, attrs authorizer.Attributes) (authorizer.Decision, string, error) {
+	if attrs.IsResourceRequest() {
+		return authorizer.DecisionNoOpinion, "", nil
+	}
+
+	if a.verbs != nil && !a.verbs.Has(attrs.GetVerb()) {
+		return authorizer.DecisionNoOpinion, "", nil
+	}
+
+	path := attrs.GetPath()
+	for _, allowed := range a.paths {
+		if matches(allowed, path) {
+			return authorizer.DecisionAllow, "", nil
+		}
+	}
+
+	return authorizer.DecisionNoOpinion, "", nil
+}
+
+// matches reports whether path equals allowed, or, if allowed ends in "*", whether path has
+// allowed's prefix (minus the "*").
+func matches(allowed, path string) bool {
+	if strings.HasSuffix(allowed, "*") {
+		return strings.HasPrefix(path, strings.TrimSuffix(allowed, "*"))
+	}
+	return path == allowed
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package path
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+)
+
+func TestAuthorize(t *testing.T) {
+	tests := []struct {
+		name             string
+		paths            []string
+		verbs            []string
+		attrs            authorizer.AttributesRecord
+		expectedDecision authorizer.Decision
+	}{
+		{
+			name:             "exact path match",
+			paths:            []string{"/healthz"},
+			attrs:            authorizer.AttributesRecord{Path: "/healthz"},
+			expectedDecision: authorizer.DecisionAllow,
+		},
+		{
+			name:             "no match",
+			paths:            []string{"/healthz"},
+			attrs:            authorizer.AttributesRecord{Path: "/apis/foo"},
+			expectedDecision: authorizer.DecisionNoOpinion,
+		},
+		{
+			name:             "trailing wildcard matches the prefix",
+			paths:            []string{"/metrics/*"},
+			attrs:            authorizer.AttributesRecord{Path: "/metrics/slis"},
+			expectedDecision: authorizer.DecisionAllow,
+		},
+		{
+			name:             "trailing wildcard does not match a path missing the prefix",
+			paths:            []string{"/metrics/*"},
+			attrs:            authorizer.AttributesRecord{Path: "/healthz"},
+			expectedDecision: authorizer.DecisionNoOpinion,
+		},
+		{
+			name:             "resource requests are never allowed regardless of path",
+			paths:            []string{"/healthz"},
+			attrs:            authorizer.AttributesRecord{Path: "/healthz", ResourceRequest: true},
+			expectedDecision: authorizer.DecisionNoOpinion,
+		},
+		{
+			name:             "empty verbs allows any verb",
+			paths:            []string{"/healthz"},
+			attrs:            authorizer.AttributesRecord{Path: "/healthz", Verb: "post"},
+			expectedDecision: authorizer.DecisionAllow,
+		},
+		{
+			name:             "a restricted verb list rejects a verb not in it",
+			paths:            []string{"/healthz"},
+			verbs:            []string{"get"},
+			attrs:            authorizer.AttributesRecord{Path: "/healthz", Verb: "post"},
+			expectedDecision: authorizer.DecisionNoOpinion,
+		},
+		{
+			name:             "a restricted verb list allows a verb in it",
+			paths:            []string{"/healthz"},
+			verbs:            []string{"get"},
+			attrs:            authorizer.AttributesRecord{Path: "/healthz", Verb: "get"},
+			expectedDecision: authorizer.DecisionAllow,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := NewAuthorizer(test.paths, test.verbs)
+			decision, _, err := a.Authorize(context.Background(), test.attrs)
+			if err != nil {
+				t.Fatalf("Authorize: %v", err)
+			}
+			if decision != test.expectedDecision {
+				t.Errorf("expected decision %v, got %v", test.expectedDecision, decision)
+			}
+		})
+	}
+}